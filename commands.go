@@ -0,0 +1,664 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"atlas.todo/internal/agenda"
+	"atlas.todo/internal/model"
+	"atlas.todo/internal/query"
+	"atlas.todo/internal/storage"
+	"atlas.todo/internal/todotxt"
+	"atlas.todo/internal/ui"
+)
+
+// Exit codes, so scripts can tell a typo'd ID from a real failure.
+const (
+	exitError     = 1 // Reading or saving tasks failed
+	exitUsage     = 2 // Missing or malformed arguments
+	exitNotFound  = 3 // No task has that ID
+	exitAmbiguous = 4 // The ID prefix matches several tasks
+)
+
+// fail prints an error and exits with code.
+func fail(code int, format string, a ...any) {
+	fmt.Fprintf(os.Stderr, "Error: "+format+"\n", a...)
+	os.Exit(code)
+}
+
+// usage prints a command's usage line and exits.
+func usage(line string) {
+	fmt.Fprintf(os.Stderr, "Usage: atlas.todo %s\n", line)
+	os.Exit(exitUsage)
+}
+
+// failQuery reports a query parse error, pointing at where it is.
+func failQuery(text string, err error) {
+	var qe *query.Error
+	if errors.As(err, &qe) {
+		col := utf8.RuneCountInString(text[:qe.Pos])
+		fmt.Fprintf(os.Stderr, "Error: %s\n  %s\n  %s^\n", qe.Msg, text, strings.Repeat(" ", col))
+		os.Exit(exitUsage)
+	}
+	fail(exitUsage, "%v", err)
+}
+
+// findTask resolves a full or short ID, exiting if it names no task or
+// more than one.
+func findTask(store storage.Backend, ref string) model.Task {
+	t, err := store.Find(ref)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		fail(exitNotFound, "%v", err)
+	case errors.Is(err, storage.ErrAmbiguous):
+		fail(exitAmbiguous, "%v (type more of the ID)", err)
+	case err != nil:
+		fail(exitError, "%v", err)
+	}
+	return t
+}
+
+// findTasks resolves every ref before anything is changed, so a bad ID
+// leaves the store untouched. Refs naming the same task, like a full ID
+// and its prefix, return it once.
+func findTasks(store storage.Backend, refs []string) []model.Task {
+	var tasks []model.Task
+	seen := map[string]bool{}
+	for _, ref := range refs {
+		if t := findTask(store, ref); !seen[t.ID] {
+			seen[t.ID] = true
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
+func save(store storage.Backend) {
+	if err := store.Save(); err != nil {
+		fail(exitError, "saving tasks: %v", err)
+	}
+}
+
+// cmdDone marks tasks done ("done") or open again ("reopen").
+func cmdDone(store storage.Backend, cmd string, args []string) {
+	if len(args) == 0 {
+		usage(cmd + " <id> [id...]")
+	}
+	done := cmd == "done"
+
+	shortIDs := store.ShortIDs()
+	for _, t := range findTasks(store, args) {
+		if t.Done == done {
+			fmt.Printf("Already %s: %s\n", status(t), t.Title)
+			continue
+		}
+		next, err := store.Toggle(t.ID)
+		if err != nil {
+			fail(exitError, "%v", err)
+		}
+		if done {
+			fmt.Printf("Done: %s\n", t.Title)
+		} else {
+			fmt.Printf("Reopened: %s\n", t.Title)
+		}
+		if next != nil {
+			fmt.Printf("  ↻ next due %s\n", next.Due.Format(model.DateLayout))
+		}
+		if open := countOpen(store.Descendants(t.ID)); done && open > 0 {
+			fmt.Printf("  %d open subtask(s) left under %s\n", open, shortIDs[t.ID])
+		}
+	}
+	save(store)
+}
+
+// cmdRemove moves tasks to the trash. Their subtasks move up a level.
+func cmdRemove(store storage.Backend, args []string) {
+	if len(args) == 0 {
+		usage("rm <id> [id...]")
+	}
+	shortIDs := store.ShortIDs()
+	for _, t := range findTasks(store, args) {
+		if err := store.Delete(t.ID); err != nil {
+			fail(exitError, "%v", err)
+		}
+		fmt.Printf("Moved to trash: %s (atlas.todo trash restore %s)\n", t.Title, shortIDs[t.ID])
+	}
+	save(store)
+}
+
+// cmdTrash lists the trash, restores or purges tasks in it, or sets how
+// long it keeps them.
+func cmdTrash(store storage.Backend, args []string) {
+	out, args := parseOutputFlags(store, args)
+	sub := ""
+	if len(args) > 0 {
+		sub, args = args[0], args[1:]
+	}
+
+	var ids []string
+	for _, ref := range args {
+		if sub != "restore" && sub != "purge" {
+			break
+		}
+		t, err := store.FindDeleted(ref)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			fail(exitNotFound, "nothing in the trash with that ID: %q", ref)
+		case errors.Is(err, storage.ErrAmbiguous):
+			fail(exitAmbiguous, "%v (type more of the ID)", err)
+		}
+		ids = append(ids, t.ID)
+	}
+
+	switch sub {
+	case "":
+		trash := store.Trash()
+		if out.machine() {
+			emit(out, trash)
+			return
+		}
+		if len(trash) == 0 {
+			fmt.Println("The trash is empty.")
+			return
+		}
+		shortIDs := store.ShortIDs()
+		for _, t := range trash {
+			fmt.Printf("%s  %s %s\n", t.DeletedAt.Local().Format(model.DateLayout), shortIDs[t.ID], t.Format())
+		}
+		if days := store.Config().TrashRetention(); days > 0 {
+			fmt.Printf("\nTasks are purged %d days after deletion.\n", days)
+		}
+	case "restore":
+		if len(ids) == 0 {
+			usage("trash restore <id> [id...]")
+		}
+		for _, id := range ids {
+			if err := store.Undelete(id); err != nil {
+				fail(exitError, "%v", err)
+			}
+			t, _ := store.Get(id)
+			fmt.Printf("Restored: %s\n", t.Title)
+		}
+		save(store)
+	case "purge":
+		if len(ids) == 0 {
+			for _, t := range store.Trash() {
+				ids = append(ids, t.ID)
+			}
+		}
+		purged := store.Remove("purge", ids...)
+		save(store)
+		fmt.Printf("Purged %d task(s).\n", len(purged))
+	case "keep":
+		if len(args) != 1 {
+			usage("trash keep <days>|forever")
+		}
+		cfg := store.Config()
+		if args[0] == "forever" {
+			cfg.TrashDays = -1
+		} else if days, err := strconv.Atoi(strings.TrimSuffix(args[0], "d")); err == nil && days > 0 {
+			cfg.TrashDays = days
+		} else {
+			fail(exitUsage, "keep takes a number of days, or forever")
+		}
+		store.SetConfig(cfg)
+		save(store)
+		if cfg.TrashDays < 0 {
+			fmt.Println("Deleted tasks now stay in the trash until purged.")
+		} else {
+			fmt.Printf("Deleted tasks are now purged after %d days.\n", cfg.TrashDays)
+		}
+	default:
+		usage("trash [restore <id>... | purge [id...] | keep <days>|forever]")
+	}
+}
+
+// cmdEdit replaces a task's text, parsed the same way as 'add'.
+func cmdEdit(store storage.Backend, args []string) {
+	if len(args) < 2 {
+		usage("edit <id> \"New text @category !priority\"")
+	}
+	t := findTask(store, args[0]).Reparse(strings.Join(args[1:], " "))
+	if t.Title == "" {
+		fail(exitUsage, "the new text has no title")
+	}
+	if err := store.Update(t); err != nil {
+		fail(exitError, "%v", err)
+	}
+	save(store)
+	fmt.Printf("Task updated: %s\n", t.Format())
+}
+
+// cmdPrio sets a task's priority.
+func cmdPrio(store storage.Backend, args []string) {
+	if len(args) != 2 {
+		usage("prio <id> high|med|low")
+	}
+	p, ok := model.ParsePriority(args[1])
+	if !ok {
+		fail(exitUsage, "unknown priority %q (use high, med or low)", args[1])
+	}
+	t := findTask(store, args[0])
+	t.Priority = p
+	if err := store.Update(t); err != nil {
+		fail(exitError, "%v", err)
+	}
+	save(store)
+	fmt.Printf("Priority %s: %s\n", p, t.Title)
+}
+
+// cmdStatus moves tasks to a board column, completing or reopening them
+// when they go into or out of done.
+func cmdStatus(store storage.Backend, args []string) {
+	if len(args) < 2 {
+		usage("status <id> [id...] <status>")
+	}
+	columns := store.Config().BoardColumns()
+	want := strings.ToLower(args[len(args)-1])
+	if !slices.Contains(columns, want) {
+		fail(exitUsage, "unknown status %q (columns are %s)", want, strings.Join(columns, ", "))
+	}
+
+	for _, t := range findTasks(store, args[:len(args)-1]) {
+		next, err := storage.SetStatus(store, t.ID, want)
+		if err != nil {
+			fail(exitError, "%v", err)
+		}
+		fmt.Printf("Moved to %s: %s\n", want, t.Title)
+		if next != nil {
+			fmt.Printf("  ↻ next due %s\n", next.Due.Format(model.DateLayout))
+		}
+	}
+	save(store)
+}
+
+// cmdColumns shows or sets the board's columns.
+func cmdColumns(store storage.Backend, args []string) {
+	cfg := store.Config()
+	switch {
+	case len(args) == 0:
+		counts := map[string]int{}
+		columns := cfg.BoardColumns()
+		for _, t := range store.Query(nil) {
+			counts[columns[storage.Column(columns, t)]]++
+		}
+		for _, c := range columns {
+			fmt.Printf("%-12s %d\n", c, counts[c])
+		}
+		return
+	case len(args) == 1 && args[0] == "reset":
+		cfg.Columns = nil
+	default:
+		columns, err := storage.ParseColumns(args)
+		if err != nil {
+			fail(exitUsage, "%v", err)
+		}
+		cfg.Columns = columns
+	}
+	store.SetConfig(cfg)
+	save(store)
+	fmt.Printf("Columns: %s\n", strings.Join(cfg.BoardColumns(), ", "))
+}
+
+// cmdAgenda prints the open tasks planned for or due on each of the next
+// few days, after the ones already overdue (which aren't repeated below).
+func cmdAgenda(store storage.Backend, args []string) {
+	out, args := parseOutputFlags(store, args)
+	days := 7
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		if name != "--days" {
+			usage("agenda [--days 7] [--json | --ndjson | --format tmpl]")
+		}
+		if !hasValue && i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			fail(exitUsage, "--days needs a number of days, e.g. --days 14")
+		}
+		days = n
+	}
+
+	now := time.Now()
+	open := store.Query(func(t model.Task) bool { return !t.Done })
+	overdue := agenda.Overdue(open, now)
+	notOverdue := slices.DeleteFunc(open, func(t model.Task) bool { return t.IsOverdue(now) })
+	upcoming := agenda.Days(notOverdue, now, days)
+	if out.machine() {
+		var entries []agendaEntry
+		for _, t := range overdue {
+			entries = append(entries, agendaEntry{Task: t, Overdue: true})
+		}
+		for _, day := range upcoming {
+			for _, e := range day.Entries {
+				entries = append(entries, agendaEntry{Task: e.Task, Day: day.Date.Format(model.DateLayout), DueThatDay: e.Due})
+			}
+		}
+		emit(out, entries)
+		return
+	}
+
+	shortIDs := store.ShortIDs()
+	line := func(t model.Task, note string) {
+		marker := " "
+		if t.Priority == model.PriorityHigh {
+			marker = "!"
+		} else if t.Priority == model.PriorityLow {
+			marker = "."
+		}
+		fmt.Printf("  [%s] %s %s%s\n", marker, shortIDs[t.ID], t.Format(), note)
+	}
+
+	if len(overdue) > 0 {
+		fmt.Println("Overdue")
+		for _, t := range overdue {
+			line(t, "")
+		}
+		fmt.Println()
+	}
+	for i, day := range upcoming {
+		heading := day.Date.Format("Mon 02 Jan")
+		switch i {
+		case 0:
+			heading = "Today · " + heading
+		case 1:
+			heading = "Tomorrow · " + heading
+		}
+		fmt.Println(heading)
+		if len(day.Entries) == 0 {
+			fmt.Println("  -")
+		}
+		for _, e := range day.Entries {
+			note := ""
+			if e.Due {
+				note = " (due)"
+			}
+			line(e.Task, note)
+		}
+	}
+}
+
+// agendaEntry is one task in the agenda's script output: overdue, or on
+// the day it's planned for or due.
+type agendaEntry struct {
+	model.Task
+	Day        string `json:"day,omitempty"`
+	Overdue    bool   `json:"overdue,omitempty"`
+	DueThatDay bool   `json:"due_that_day,omitempty"`
+}
+
+// cmdShow prints everything known about a task.
+func cmdShow(store storage.Backend, args []string) {
+	out, args := parseOutputFlags(store, args)
+	if len(args) != 1 {
+		usage("show <id> [--json | --ndjson | --format tmpl]")
+	}
+	t := findTask(store, args[0])
+	if out.machine() {
+		emitOne(out, t)
+		return
+	}
+	for _, f := range ui.Details(store, t, time.Now()) {
+		fmt.Printf("%-12s %s\n", f.Name+":", f.Value)
+	}
+	if t.Description != "" {
+		fmt.Printf("\n%s\n", ui.RenderMarkdown(t.Description, terminalWidth()))
+	}
+}
+
+func status(t model.Task) string {
+	if t.Done {
+		return "done"
+	}
+	return "open"
+}
+
+func countOpen(tasks []model.Task) int {
+	n := 0
+	for _, t := range tasks {
+		if !t.Done {
+			n++
+		}
+	}
+	return n
+}
+
+// findView looks up a saved view by name, exiting if there's none.
+func findView(store storage.Backend, name string) storage.View {
+	v, ok := store.Config().View(name)
+	if !ok {
+		fail(exitNotFound, "no view named %q (see atlas.todo views)", name)
+	}
+	return v
+}
+
+// cmdViews lists, adds or removes saved views.
+func cmdViews(store storage.Backend, args []string) {
+	out, args := parseOutputFlags(store, args)
+	cfg := store.Config()
+
+	if len(args) == 0 {
+		if out.machine() {
+			emit(out, cfg.Views)
+			return
+		}
+		if len(cfg.Views) == 0 {
+			fmt.Println("No saved views. Press S in the TUI, or run 'atlas.todo views add <name> <query>'.")
+			return
+		}
+		for i, v := range cfg.Views {
+			key := " "
+			if i < 9 {
+				key = fmt.Sprint(i + 1)
+			}
+			fmt.Printf("%s  %-20s %s\n", key, v.Name, v.Query)
+		}
+		return
+	}
+
+	switch args[0] {
+	case "add":
+		if len(args) < 2 {
+			usage("views add <name> [query]")
+		}
+		text := strings.Join(args[2:], " ")
+		if _, err := query.Parse(text, time.Now()); err != nil {
+			failQuery(text, err)
+		}
+		cfg.SetView(storage.View{Name: args[1], Query: text})
+		store.SetConfig(cfg)
+		save(store)
+		fmt.Printf("Saved view %q.\n", args[1])
+	case "rm":
+		if len(args) != 2 {
+			usage("views rm <name>")
+		}
+		if !cfg.DeleteView(args[1]) {
+			fail(exitNotFound, "no view named %q", args[1])
+		}
+		store.SetConfig(cfg)
+		save(store)
+		fmt.Printf("Deleted view %q.\n", args[1])
+	default:
+		usage("views [add <name> [query] | rm <name>]")
+	}
+}
+
+// cmdArchive moves finished tasks into the archive next to the task file,
+// lists what's there, or restores tasks from it.
+func cmdArchive(store storage.Backend, args []string) {
+	out, args := parseOutputFlags(store, args)
+	archive, err := store.OpenArchive()
+	if err != nil {
+		fail(exitError, "opening the archive: %v", err)
+	}
+
+	if len(args) > 0 && args[0] == "list" {
+		tasks := archive.Query(nil)
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return storage.CompletedAt(tasks[i]).After(storage.CompletedAt(tasks[j]))
+		})
+		if out.machine() {
+			emit(out, tasks)
+			return
+		}
+		if len(tasks) == 0 {
+			fmt.Println("The archive is empty.")
+			return
+		}
+		shortIDs := archive.ShortIDs()
+		for _, t := range tasks {
+			fmt.Printf("%s  %s %s\n", storage.CompletedAt(t).Local().Format(model.DateLayout), shortIDs[t.ID], t.Format())
+		}
+		return
+	}
+
+	if len(args) > 0 && args[0] == "restore" {
+		if len(args) < 2 {
+			usage("archive restore <id> [id...]")
+		}
+		var ids []string
+		for _, t := range findTasks(archive, args[1:]) {
+			ids = append(ids, t.ID)
+		}
+		restored, err := storage.Unarchive(store, archive, ids...)
+		if err != nil {
+			fail(exitError, "restoring tasks: %v", err)
+		}
+		for _, t := range restored {
+			fmt.Printf("Restored: %s\n", t.Title)
+		}
+		return
+	}
+
+	cutoff := time.Now()
+	for i := 0; i < len(args); i++ {
+		value, ok := strings.CutPrefix(args[i], "--older-than=")
+		if !ok && args[i] == "--older-than" && i+1 < len(args) {
+			i++
+			value, ok = args[i], true
+		}
+		if !ok {
+			usage("archive [--older-than 30d] | archive list | archive restore <id>")
+		}
+		value = strings.TrimPrefix(value, "+")
+		n, err := strconv.Atoi(value[:max(len(value)-1, 0)])
+		if err != nil || n < 0 {
+			fail(exitUsage, "--older-than takes a period like 30d, 2w, 6m or 1y")
+		}
+		if cutoff, ok = model.AddPeriod(cutoff, -n, value[len(value)-1]); !ok {
+			fail(exitUsage, "--older-than takes a period like 30d, 2w, 6m or 1y")
+		}
+	}
+
+	moved, err := storage.Archive(store, archive, cutoff)
+	if err != nil {
+		fail(exitError, "archiving tasks: %v", err)
+	}
+	if len(moved) == 0 {
+		fmt.Println("Nothing to archive.")
+		return
+	}
+	fmt.Printf("Archived %d task(s).\n", len(moved))
+}
+
+// cmdPrompt prints task counts for a shell prompt, e.g. "{pending} {high}!".
+// It runs before the task file is loaded and reads the summary cache, so
+// it stays fast however many tasks there are.
+func cmdPrompt(path string, args []string) {
+	format := "{pending}"
+	if len(args) > 0 {
+		format = strings.Join(args, " ")
+	}
+	sum, err := storage.ReadSummary(path, time.Now())
+	if err != nil {
+		fail(exitError, "reading task counts: %v", err)
+	}
+	counts := map[string]int{
+		"pending": sum.Pending,
+		"high":    sum.High,
+		"med":     sum.Medium,
+		"low":     sum.Low,
+		"overdue": sum.Overdue,
+		"today":   sum.Today,
+		"done":    sum.Done,
+		"total":   sum.Pending + sum.Done,
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(format, '{')
+		end := strings.IndexByte(format[start+1:], '}')
+		if start < 0 || end < 0 {
+			break
+		}
+		name := format[start+1 : start+1+end]
+		n, ok := counts[name]
+		if !ok {
+			fail(exitUsage, "unknown placeholder {%s}; use {pending} {high} {med} {low} {overdue} {today} {done} or {total}", name)
+		}
+		b.WriteString(format[:start] + strconv.Itoa(n))
+		format = format[start+2+end:]
+	}
+	b.WriteString(format)
+	// No newline: the output goes in the middle of a prompt
+	fmt.Print(b.String())
+}
+
+// cmdImport adds the tasks in a todo.txt file, or stdin for "-".
+func cmdImport(store storage.Backend, args []string) {
+	if len(args) != 2 || args[0] != "todotxt" {
+		usage("import todotxt <file | ->")
+	}
+	var data []byte
+	var err error
+	if args[1] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[1])
+	}
+	if err != nil {
+		fail(exitError, "reading %s: %v", args[1], err)
+	}
+	added, updated, err := storage.ImportTodoTxt(store, strings.Split(string(data), "\n"), time.Now())
+	if err != nil {
+		fail(exitError, "importing tasks: %v", err)
+	}
+	save(store)
+	fmt.Printf("Imported %d new task(s), updated %d.\n", added, updated)
+}
+
+// cmdExport prints every task, finished ones included, as todo.txt.
+func cmdExport(store storage.Backend, args []string) {
+	if len(args) != 1 || args[0] != "todotxt" {
+		usage("export todotxt")
+	}
+	for _, t := range store.Query(nil) {
+		fmt.Println(todotxt.Format(t))
+	}
+}
+
+// cmdSync reconciles a todo.txt file with the store in both directions.
+func cmdSync(store storage.Backend, path string, args []string) {
+	if len(args) != 2 || args[0] != "todotxt" {
+		usage("sync todotxt <file>")
+	}
+	res, err := storage.SyncTodoTxt(store, path, args[1], time.Now())
+	if err != nil {
+		fail(exitError, "syncing %s: %v", args[1], err)
+	}
+	fmt.Printf("Synced %s: %d change(s) to your tasks, %d to the file.\n", args[1], res.Store, res.File)
+	if res.Conflicts > 0 {
+		fmt.Printf("%d task(s) were changed on both sides; your tasks' version was kept.\n", res.Conflicts)
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"atlas.todo/internal/model"
+	"atlas.todo/internal/storage"
+)
+
+// TestMain runs the command line instead of the tests when run asks it
+// to, since main exits with the code under test.
+func TestMain(m *testing.M) {
+	if args, ok := os.LookupEnv("ATLAS_TODO_TEST_ARGS"); ok {
+		os.Args = append([]string{"atlas.todo"}, strings.Split(args, "\n")...)
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// run runs atlas.todo with args on the task file at path and returns what
+// it printed and its exit code.
+func run(t *testing.T, path string, args ...string) (stdout, stderr string, code int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(),
+		"ATLAS_TODO_TEST_ARGS="+strings.Join(args, "\n"),
+		"ATLAS_TODO_FILE="+path,
+		"HOME="+filepath.Dir(path),
+	)
+	var out, errOut bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &errOut
+	err := cmd.Run()
+	var exit *exec.ExitError
+	if errors.As(err, &exit) {
+		code = exit.ExitCode()
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	return out.String(), errOut.String(), code
+}
+
+// seed writes a task file holding tasks and returns its path.
+func seed(t *testing.T, tasks ...model.Task) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "todo.json")
+	store, err := storage.NewJSONStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, task := range tasks {
+		store.Add(task)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// load reads back the tasks in the file at path.
+func load(t *testing.T, path string) []model.Task {
+	t.Helper()
+	store, err := storage.NewJSONStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Load(); err != nil {
+		t.Fatal(err)
+	}
+	return store.Query(nil)
+}
+
+func TestExitCodes(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{"done", "01ABCDEF"}, 0},
+		{[]string{"reopen", "01abcdef0000"}, 0},
+		{[]string{"show", "01ZZ"}, 0},
+		{[]string{"prio", "01ZZ", "high"}, 0},
+		{[]string{"edit", "01ZZ", "Call the bank !high"}, 0},
+		{[]string{"rm", "01ZZ"}, 0},
+		{[]string{"add", "Water the plants"}, 0},
+		{[]string{"add"}, exitUsage},
+		{[]string{"restore", "latest"}, exitUsage},
+		{[]string{"done"}, exitUsage},
+		{[]string{"prio", "01ZZ"}, exitUsage},
+		{[]string{"prio", "01ZZ", "urgent"}, exitUsage},
+		{[]string{"edit", "01ZZ"}, exitUsage},
+		{[]string{"edit", "01ZZ", "@home"}, exitUsage},
+		{[]string{"done", "01X"}, exitNotFound},
+		{[]string{"show", "01ZZ", "01X"}, exitUsage},
+		{[]string{"rm", "01ZZ", "01X"}, exitNotFound},
+		{[]string{"done", "01ABCDE"}, exitAmbiguous},
+		{[]string{"show", "01"}, exitAmbiguous},
+	}
+	for _, tt := range tests {
+		name := strings.Join(tt.args, " ")
+		t.Run(name, func(t *testing.T) {
+			path := seed(t,
+				model.Task{ID: "01ABCDEF0000", Title: "Buy milk"},
+				model.Task{ID: "01ABCDEG0000", Title: "Pay rent"},
+				model.Task{ID: "01ZZZZZZ0000", Title: "Call bank"},
+			)
+			stdout, stderr, code := run(t, path, tt.args...)
+			if code != tt.code {
+				t.Errorf("exit code %d, want %d (stderr %q)", code, tt.code, stderr)
+			}
+			if code != 0 && stderr == "" {
+				t.Error("failed without saying why on stderr")
+			}
+			if code != 0 && stdout != "" {
+				t.Errorf("failed but printed %q on stdout", stdout)
+			}
+		})
+	}
+}
+
+// TestAddPrintsID checks that add prints only the new task's short ID, which
+// the other commands accept.
+func TestAddPrintsID(t *testing.T) {
+	path := seed(t, model.Task{ID: "01ABCDEF0000", Title: "Buy milk"})
+	out, _, code := run(t, path, "add", "Call the bank")
+	id := strings.TrimSpace(out)
+	if code != 0 || id == "" || strings.ContainsAny(id, " \n") {
+		t.Fatalf("add printed %q (exit %d), want just the ID", out, code)
+	}
+	if _, stderr, code := run(t, path, "done", id); code != 0 {
+		t.Fatalf("done %s: exit %d, %s", id, code, stderr)
+	}
+	for _, task := range load(t, path) {
+		if task.Done != (task.Title == "Call the bank") {
+			t.Errorf("%q done = %v after done %s", task.Title, task.Done, id)
+		}
+	}
+}
+
+// TestBadIDChangesNothing checks that a command naming a missing task
+// among good ones doesn't change the good ones either.
+func TestBadIDChangesNothing(t *testing.T) {
+	path := seed(t,
+		model.Task{ID: "01ABCDEF0000", Title: "Buy milk"},
+		model.Task{ID: "01ZZZZZZ0000", Title: "Call bank"},
+	)
+	if _, _, code := run(t, path, "done", "01ABCDEF", "01X"); code != exitNotFound {
+		t.Fatalf("exit code %d, want %d", code, exitNotFound)
+	}
+	if load(t, path)[0].Done {
+		t.Error("the task was marked done anyway")
+	}
+}
+
+// TestSameTaskTwice checks that naming a task twice, by its full ID and a
+// prefix, toggles it once rather than back again.
+func TestSameTaskTwice(t *testing.T) {
+	path := seed(t, model.Task{ID: "01ABCDEF0000", Title: "Buy milk"})
+	if _, stderr, code := run(t, path, "done", "01ABCDEF0000", "01abcd"); code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	if !load(t, path)[0].Done {
+		t.Error("the task is open again")
+	}
+}
+
+// TestListCount checks that only the first number is the count, so later
+// ones are searched for.
+func TestListCount(t *testing.T) {
+	path := seed(t,
+		model.Task{ID: "a", Title: "Renew passport 2026"},
+		model.Task{ID: "b", Title: "Buy milk"},
+		model.Task{ID: "c", Title: "Call bank"},
+	)
+	tests := []struct {
+		args  []string
+		lines int
+		code  int
+	}{
+		{[]string{"list"}, 3, 0},
+		{[]string{"list", "2"}, 2, 0},
+		{[]string{"list", "5", "2026"}, 1, 0},
+		{[]string{"list", "0"}, 0, exitUsage},
+		{[]string{"list", "-1"}, 0, exitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			out, _, code := run(t, path, append(tt.args, "--format={{.Title}}")...)
+			if lines := strings.Count(out, "\n"); lines != tt.lines || code != tt.code {
+				t.Errorf("printed %d task(s), exit code %d; want %d, %d", lines, code, tt.lines, tt.code)
+			}
+		})
+	}
+}
+
+func TestPrompt(t *testing.T) {
+	path := seed(t,
+		model.Task{ID: "a", Title: "Buy milk", Priority: model.PriorityHigh},
+		model.Task{ID: "b", Title: "Call bank", Priority: model.PriorityLow},
+		model.Task{ID: "c", Title: "Pay rent", Done: true},
+	)
+	tests := []struct {
+		args []string
+		want string
+		code int
+	}{
+		{nil, "2", 0},
+		{[]string{"{pending}", "({high}!)"}, "2 (1!)", 0},
+		{[]string{"{low}/{done}/{total}"}, "1/1/3", 0},
+		{[]string{"no placeholders"}, "no placeholders", 0},
+		{[]string{"{pending"}, "{pending", 0},
+		{[]string{"{nope}"}, "", exitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			out, _, code := run(t, path, append([]string{"prompt"}, tt.args...)...)
+			if out != tt.want || code != tt.code {
+				t.Errorf("printed %q, exit code %d; want %q, %d", out, code, tt.want, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"atlas.todo/internal/model"
+	"atlas.todo/internal/storage"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultBanner is shown above the dashboard unless banner.txt in the data
+// directory or --banner says otherwise.
+const defaultBanner = `
+     _____                              .___
+   _/ ____\____ ________ ____  ____   __| _/____
+   \   __\/ __ \___   // ___\/  _ \ / __ |/ __ \
+    |  | \  ___/ /    /\  \__(  <_> ) /_/ \  ___/
+    |__|  \___  >_____ \___  >____/\____ |\___  >
+              \/      \/    \/           \/    \/
+`
+
+// The dashboard's colours. lipgloss drops them when NO_COLOR is set or
+// stdout isn't a terminal.
+var (
+	bannerStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("5"))
+	greetStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Bold(true)
+	highStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	medStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	lowStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	clearStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	overdueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
+	mutedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("7"))
+)
+
+// cmdDashboard prints a greeting for a new shell: a banner, the top tasks by
+// priority, and how many are overdue and how long the completion streak is.
+func cmdDashboard(store storage.Backend, dir string, args []string) {
+	top := 3
+	bannerFile := filepath.Join(dir, "banner.txt")
+	showBanner := true
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		switch name {
+		case "--no-banner":
+			showBanner = false
+			continue
+		case "--top", "--banner":
+		default:
+			usage("dashboard [--top 3] [--banner file | --no-banner]")
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				fail(exitUsage, "%s needs a value", name)
+			}
+			i++
+			value = args[i]
+		}
+		if name == "--banner" {
+			bannerFile = value
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			fail(exitUsage, "--top needs a number of tasks, e.g. --top 5")
+		}
+		top = n
+	}
+
+	width := terminalWidth()
+	if width == 0 {
+		width = 80
+	}
+	now := time.Now()
+	var b strings.Builder
+
+	if showBanner {
+		banner := defaultBanner
+		if data, err := os.ReadFile(bannerFile); err == nil {
+			banner = string(data)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			fail(exitError, "reading the banner: %v", err)
+		}
+		// A banner that would wrap is only noise
+		if lipgloss.Width(banner) <= width {
+			b.WriteString(bannerStyle.Render(strings.Trim(banner, "\n")) + "\n\n")
+		}
+	}
+
+	pending := store.Query(func(t model.Task) bool { return !t.Done })
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].Priority > pending[j].Priority
+	})
+
+	fmt.Fprintf(&b, "  %s\n\n", greetStyle.Render(fmt.Sprintf("%s, %s! Here's what might want your focus:", greeting(now), userName())))
+	if len(pending) == 0 {
+		b.WriteString("  " + clearStyle.Render("✨ Your board is clear! Ready for something new?") + "\n")
+	}
+	for _, t := range pending[:min(top, len(pending))] {
+		line := clip(t.Format(), width-6)
+		switch t.Priority {
+		case model.PriorityHigh:
+			line = highStyle.Render(line + "  🔥")
+		case model.PriorityLow:
+			line = lowStyle.Render(line)
+		default:
+			line = medStyle.Render(line)
+		}
+		b.WriteString("  " + line + "\n")
+	}
+
+	var stats []string
+	if n := len(pending); n > top {
+		stats = append(stats, fmt.Sprintf("%d more open", n-top))
+	}
+	overdue := 0
+	for _, t := range pending {
+		if t.IsOverdue(now) {
+			overdue++
+		}
+	}
+	if overdue > 0 {
+		stats = append(stats, overdueStyle.Render(fmt.Sprintf("⚠ %d overdue", overdue)))
+	}
+	if days := streak(completions(store), now); days > 0 {
+		stats = append(stats, fmt.Sprintf("%d-day streak", days))
+	}
+	if len(stats) > 0 {
+		b.WriteString("\n  " + strings.Join(stats, mutedStyle.Render(" • ")) + "\n")
+	}
+
+	b.WriteString("\n  " + lowStyle.Render(strings.Repeat("─", min(50, width-4))) + "\n")
+	b.WriteString("  " + mutedStyle.Render("Run 'atlas.todo' to manage tasks.") + "\n")
+	fmt.Print(b.String())
+}
+
+// greeting says hello for the time of day.
+func greeting(now time.Time) string {
+	switch h := now.Hour(); {
+	case h < 12:
+		return "Good Morning"
+	case h < 18:
+		return "Good Afternoon"
+	}
+	return "Good Evening"
+}
+
+func userName() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		// Windows reports DOMAIN\name
+		return u.Username[strings.LastIndex(u.Username, `\`)+1:]
+	}
+	for _, env := range []string{"USER", "USERNAME"} {
+		if name := os.Getenv(env); name != "" {
+			return name
+		}
+	}
+	return "there"
+}
+
+// completions returns when every finished task, archived ones included,
+// was completed. Tasks finished before completion times were recorded are
+// left out rather than counted on the day they were created.
+func completions(store storage.Backend) []time.Time {
+	tasks := store.Query(func(t model.Task) bool { return t.Done })
+	if archive, err := store.OpenArchive(); err == nil {
+		tasks = append(tasks, archive.Query(nil)...)
+	}
+	var times []time.Time
+	for _, t := range tasks {
+		if t.CompletedAt != nil {
+			times = append(times, *t.CompletedAt)
+		}
+	}
+	return times
+}
+
+// streak counts the days in a row, up to today, on which something was
+// completed. A streak that reached yesterday still counts until today is
+// over.
+func streak(completed []time.Time, now time.Time) int {
+	days := make(map[string]bool, len(completed))
+	for _, t := range completed {
+		days[t.Local().Format(model.DateLayout)] = true
+	}
+	day := model.StartOfDay(now)
+	if !days[day.Format(model.DateLayout)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	n := 0
+	for ; days[day.Format(model.DateLayout)]; day = day.AddDate(0, 0, -1) {
+		n++
+	}
+	return n
+}
+
+// clip cuts s to width cells, ending it with … if anything was cut.
+func clip(s string, width int) string {
+	if width < 1 || lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for lipgloss.Width(string(runes)) > width-1 {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
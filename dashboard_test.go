@@ -0,0 +1,87 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"atlas.todo/internal/model"
+	"atlas.todo/internal/storage"
+)
+
+func TestStreak(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local)
+	day := func(offset int) time.Time {
+		return model.StartOfDay(now).AddDate(0, 0, offset).Add(9 * time.Hour)
+	}
+	tests := []struct {
+		name      string
+		completed []time.Time
+		want      int
+	}{
+		{"nothing done", nil, 0},
+		{"today only", []time.Time{day(0)}, 1},
+		{"three days to today", []time.Time{day(0), day(-1), day(-1), day(-2)}, 3},
+		{"up to yesterday", []time.Time{day(-1), day(-2)}, 2},
+		{"broken yesterday", []time.Time{day(0), day(-2), day(-3)}, 1},
+		{"last done two days ago", []time.Time{day(-2), day(-3)}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := streak(tt.completed, now); got != tt.want {
+				t.Errorf("streak = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompletions(t *testing.T) {
+	done := time.Date(2026, 10, 12, 9, 0, 0, 0, time.Local)
+	archived := time.Date(2026, 10, 11, 9, 0, 0, 0, time.Local)
+	store := storage.NewMemoryStore(
+		model.Task{ID: "a", Title: "done", Done: true, CompletedAt: &done},
+		model.Task{ID: "b", Title: "open"},
+		model.Task{ID: "d", Title: "done before completion times", Done: true, CreatedAt: archived},
+	)
+	archive, err := store.OpenArchive()
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive.Add(model.Task{ID: "c", Title: "archived", Done: true, CompletedAt: &archived})
+
+	got := completions(store)
+	slices.SortFunc(got, time.Time.Compare)
+	if want := []time.Time{archived, done}; !slices.EqualFunc(got, want, time.Time.Equal) {
+		t.Errorf("completions = %v, want %v", got, want)
+	}
+}
+
+func TestGreeting(t *testing.T) {
+	for hour, want := range map[int]string{
+		0: "Good Morning", 11: "Good Morning", 12: "Good Afternoon",
+		17: "Good Afternoon", 18: "Good Evening", 23: "Good Evening",
+	} {
+		if got := greeting(time.Date(2026, 10, 14, hour, 30, 0, 0, time.Local)); got != want {
+			t.Errorf("greeting at %d:30 = %q, want %q", hour, got, want)
+		}
+	}
+}
+
+func TestClip(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"Buy milk", 20, "Buy milk"},
+		{"Buy milk", 8, "Buy milk"},
+		{"Buy milk", 5, "Buy …"},
+		{"日本語のタスク", 7, "日本語…"},
+		{"Buy milk", 0, "Buy milk"},
+	}
+	for _, tt := range tests {
+		if got := clip(tt.s, tt.width); got != tt.want {
+			t.Errorf("clip(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
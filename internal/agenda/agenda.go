@@ -0,0 +1,73 @@
+// Package agenda lays tasks out by day, for the calendar in the TUI and
+// 'atlas.todo agenda'.
+package agenda
+
+import (
+	"sort"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+// Entry is a task on a day: the day it's planned for (see model.Task.Day)
+// or the day it's due.
+type Entry struct {
+	Task model.Task
+	Due  bool // Here because it's due, not because it's planned
+}
+
+// Day is one calendar day and what falls on it.
+type Day struct {
+	Date    time.Time
+	Entries []Entry
+}
+
+// Days returns n days starting at from, each with the tasks planned for
+// or due on it. A task planned and due the same day is listed once.
+func Days(tasks []model.Task, from time.Time, n int) []Day {
+	days := make([]Day, n)
+	index := make(map[string]int, n)
+	for i := range days {
+		days[i].Date = model.StartOfDay(from).AddDate(0, 0, i)
+		index[days[i].Date.Format(model.DateLayout)] = i
+	}
+	dayOf := func(d time.Time) int {
+		if i, ok := index[d.Local().Format(model.DateLayout)]; ok {
+			return i
+		}
+		return -1
+	}
+
+	for _, t := range tasks {
+		planned := dayOf(t.Day())
+		if planned >= 0 {
+			days[planned].Entries = append(days[planned].Entries, Entry{Task: t})
+		}
+		if t.Due != nil {
+			if due := dayOf(*t.Due); due >= 0 && due != planned {
+				days[due].Entries = append(days[due].Entries, Entry{Task: t, Due: true})
+			}
+		}
+	}
+	for _, d := range days {
+		sort.SliceStable(d.Entries, func(i, j int) bool {
+			return d.Entries[i].Task.Priority > d.Entries[j].Task.Priority
+		})
+	}
+	return days
+}
+
+// Overdue returns the open tasks whose due date has passed, the longest
+// overdue first.
+func Overdue(tasks []model.Task, now time.Time) []model.Task {
+	var overdue []model.Task
+	for _, t := range tasks {
+		if t.IsOverdue(now) {
+			overdue = append(overdue, t)
+		}
+	}
+	sort.SliceStable(overdue, func(i, j int) bool {
+		return overdue[i].Due.Before(*overdue[j].Due)
+	})
+	return overdue
+}
@@ -0,0 +1,72 @@
+package agenda
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+// A Wednesday afternoon
+var now = time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local)
+
+func day(offset int) *time.Time {
+	d := model.StartOfDay(now).AddDate(0, 0, offset)
+	return &d
+}
+
+func TestDays(t *testing.T) {
+	tasks := []model.Task{
+		{Title: "created today", CreatedAt: now},
+		{Title: "planned tomorrow", CreatedAt: *day(-5), Scheduled: day(1)},
+		{Title: "due tomorrow", CreatedAt: *day(-5), Scheduled: day(-2), Due: day(1)},
+		{Title: "planned and due", CreatedAt: now, Scheduled: day(2), Due: day(2)},
+		{Title: "urgent", CreatedAt: now, Scheduled: day(2), Priority: model.PriorityHigh},
+		{Title: "next week", CreatedAt: now, Scheduled: day(7)},
+		{Title: "created last week", CreatedAt: *day(-7)},
+	}
+	days := Days(tasks, now, 3)
+
+	want := [][]string{
+		{"created today"},
+		{"planned tomorrow", "due tomorrow (due)"},
+		{"urgent", "planned and due"},
+	}
+	if len(days) != len(want) {
+		t.Fatalf("%d days, want %d", len(days), len(want))
+	}
+	for i, d := range days {
+		if !d.Date.Equal(*day(i)) {
+			t.Errorf("day %d is %v, want %v", i, d.Date, *day(i))
+		}
+		var got []string
+		for _, e := range d.Entries {
+			title := e.Task.Title
+			if e.Due {
+				title += " (due)"
+			}
+			got = append(got, title)
+		}
+		if !slices.Equal(got, want[i]) {
+			t.Errorf("day %d lists %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestOverdue(t *testing.T) {
+	tasks := []model.Task{
+		{Title: "yesterday", Due: day(-1)},
+		{Title: "today", Due: day(0)},
+		{Title: "last week", Due: day(-7)},
+		{Title: "done", Due: day(-3), Done: true},
+		{Title: "no due date"},
+	}
+	var got []string
+	for _, task := range Overdue(tasks, now) {
+		got = append(got, task.Title)
+	}
+	if want := []string{"last week", "yesterday"}; !slices.Equal(got, want) {
+		t.Errorf("Overdue = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const DateLayout = "2006-01-02"
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// StartOfDay truncates t to local midnight.
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// ParseDate resolves a date expression relative to now. It understands
+// absolute dates (2026-11-01), today/tomorrow/yesterday, weekday names
+// (the next occurrence after today), offsets like +3d, +2w, +1m, +1y and
+// the anchors eow, eom and eoy.
+func ParseDate(s string, now time.Time) (time.Time, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	today := StartOfDay(now)
+
+	if d, err := time.ParseInLocation(DateLayout, s, now.Location()); err == nil {
+		return d, true
+	}
+
+	switch s {
+	case "today", "now":
+		return today, true
+	case "tomorrow", "tmr":
+		return today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	case "eow":
+		// Weeks end on Sunday
+		days := (7 - int(today.Weekday())) % 7
+		return today.AddDate(0, 0, days), true
+	case "eom":
+		return time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, today.Location()), true
+	case "eoy":
+		return time.Date(today.Year(), time.December, 31, 0, 0, 0, 0, today.Location()), true
+	}
+
+	if wd, ok := weekdays[s]; ok {
+		days := (int(wd) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), true
+	}
+
+	if strings.HasPrefix(s, "+") && len(s) > 2 {
+		n, err := strconv.Atoi(s[1 : len(s)-1])
+		if err != nil || n < 0 {
+			return time.Time{}, false
+		}
+		return AddPeriod(today, n, s[len(s)-1])
+	}
+
+	return time.Time{}, false
+}
+
+// AddPeriod adds n units to t, where unit is one of d, w, m or y.
+func AddPeriod(t time.Time, n int, unit byte) (time.Time, bool) {
+	switch unit {
+	case 'd':
+		return t.AddDate(0, 0, n), true
+	case 'w':
+		return t.AddDate(0, 0, 7*n), true
+	case 'm':
+		return t.AddDate(0, n, 0), true
+	case 'y':
+		return t.AddDate(n, 0, 0), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	// A Wednesday afternoon
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want string // "" when the expression isn't a date
+	}{
+		{"2026-11-01", "2026-11-01"},
+		{"today", "2026-10-14"},
+		{"now", "2026-10-14"},
+		{"tomorrow", "2026-10-15"},
+		{"TMR", "2026-10-15"},
+		{"yesterday", "2026-10-13"},
+		{"fri", "2026-10-16"},
+		{"friday", "2026-10-16"},
+		{"wed", "2026-10-21"}, // Never today
+		{"mon", "2026-10-19"},
+		{"+3d", "2026-10-17"},
+		{"+2w", "2026-10-28"},
+		{"+1m", "2026-11-14"},
+		{"+1y", "2027-10-14"},
+		{"+0d", "2026-10-14"},
+		{"eow", "2026-10-18"},
+		{"eom", "2026-10-31"},
+		{"eoy", "2026-12-31"},
+		{" Tomorrow ", "2026-10-15"},
+		{"", ""},
+		{"someday", ""},
+		{"2026-13-01", ""},
+		{"+xd", ""},
+		{"+3q", ""},
+		{"+-1d", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := ParseDate(tt.in, now)
+			if tt.want == "" {
+				if ok {
+					t.Fatalf("ParseDate(%q) = %s, want no date", tt.in, got.Format(DateLayout))
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("ParseDate(%q) found no date, want %s", tt.in, tt.want)
+			}
+			if got.Format(DateLayout) != tt.want || !got.Equal(StartOfDay(got)) {
+				t.Errorf("ParseDate(%q) = %s, want midnight on %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEndOfWeekOnSunday(t *testing.T) {
+	sunday := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	got, _ := ParseDate("eow", sunday)
+	if got.Format(DateLayout) != "2026-10-18" {
+		t.Errorf("eow on a Sunday = %s, want the same day", got.Format(DateLayout))
+	}
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"crypto/rand"
+	"sync"
+	"time"
+)
+
+// Crockford's base32 alphabet, as used by ULIDs
+const idAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var (
+	idMu      sync.Mutex
+	idLastMs  int64
+	idLastRnd [10]byte
+)
+
+// NewID returns a 26-character ULID-style identifier: a 48-bit millisecond
+// timestamp followed by 80 random bits, Crockford base32 encoded. IDs sort
+// by creation time, and IDs made within the same millisecond increment the
+// random part so they stay unique and ordered.
+func NewID() string {
+	idMu.Lock()
+	defer idMu.Unlock()
+
+	ms := time.Now().UnixMilli()
+	if ms <= idLastMs {
+		ms = idLastMs
+		for i := len(idLastRnd) - 1; i >= 0; i-- {
+			idLastRnd[i]++
+			if idLastRnd[i] != 0 {
+				break
+			}
+		}
+	} else {
+		idLastMs = ms
+		if _, err := rand.Read(idLastRnd[:]); err != nil {
+			panic("atlas.todo: no randomness for task IDs: " + err.Error())
+		}
+	}
+
+	var raw [16]byte
+	for i := 0; i < 6; i++ {
+		raw[i] = byte(ms >> (40 - 8*i))
+	}
+	copy(raw[6:], idLastRnd[:])
+
+	// 128 bits in 26 five-bit groups; the first group holds only 3 bits
+	var out [26]byte
+	for i := 0; i < 26; i++ {
+		bit := 128 - 5*(26-i)
+		var v byte
+		for b := 0; b < 5; b++ {
+			pos := bit + b
+			if pos < 0 {
+				continue
+			}
+			v = v<<1 | (raw[pos/8]>>(7-pos%8))&1
+		}
+		out[i] = idAlphabet[v]
+	}
+	return string(out[:])
+}
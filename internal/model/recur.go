@@ -0,0 +1,103 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NextDue computes the due date of the instance that replaces a recurring
+// task completed at done. Supported rules:
+//
+//	daily, weekly, monthly, yearly   one period after the previous due date
+//	weekly:mon                       the next Monday
+//	monthly:15                       the next 15th (clamped to short months)
+//	+3d, +2w, +1m, +1y               measured from the completion date
+//
+// Calendar rules keep their schedule: they advance from the previous due
+// date until they land after the completion day, so a chore finished late
+// doesn't drift.
+func NextDue(rule string, due *time.Time, done time.Time) (time.Time, bool) {
+	rule = strings.ToLower(strings.TrimSpace(rule))
+	today := StartOfDay(done)
+
+	// Relative to completion
+	if strings.HasPrefix(rule, "+") && len(rule) > 2 {
+		n, err := strconv.Atoi(rule[1 : len(rule)-1])
+		if err != nil || n <= 0 {
+			return time.Time{}, false
+		}
+		return AddPeriod(today, n, rule[len(rule)-1])
+	}
+
+	base := today
+	if due != nil {
+		base = StartOfDay(*due)
+	}
+
+	kind, arg, _ := strings.Cut(rule, ":")
+	var step func(time.Time) time.Time
+
+	switch kind {
+	case "daily":
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case "weekly":
+		if arg == "" {
+			step = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+			break
+		}
+		wd, ok := weekdays[arg]
+		if !ok {
+			return time.Time{}, false
+		}
+		step = func(t time.Time) time.Time {
+			days := (int(wd) - int(t.Weekday()) + 7) % 7
+			if days == 0 {
+				days = 7
+			}
+			return t.AddDate(0, 0, days)
+		}
+	case "monthly":
+		if arg == "" {
+			day := base.Day()
+			step = func(t time.Time) time.Time { return monthDay(t.Year(), t.Month()+1, day, t.Location()) }
+			break
+		}
+		day, err := strconv.Atoi(arg)
+		if err != nil || day < 1 || day > 31 {
+			return time.Time{}, false
+		}
+		step = func(t time.Time) time.Time {
+			next := monthDay(t.Year(), t.Month(), day, t.Location())
+			if !next.After(t) {
+				next = monthDay(t.Year(), t.Month()+1, day, t.Location())
+			}
+			return next
+		}
+	case "yearly":
+		step = func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }
+	default:
+		return time.Time{}, false
+	}
+
+	next := step(base)
+	for !next.After(today) {
+		next = step(next)
+	}
+	return next, true
+}
+
+// ValidRecurrence reports whether rule is understood by NextDue.
+func ValidRecurrence(rule string) bool {
+	_, ok := NextDue(rule, nil, time.Now())
+	return ok
+}
+
+// monthDay returns the given day of a month, clamped to the month's length.
+func monthDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextDue(t *testing.T) {
+	day := func(s string) *time.Time {
+		d, err := time.ParseInLocation(DateLayout, s, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &d
+	}
+
+	tests := []struct {
+		name string
+		rule string
+		due  string // "" for no due date
+		done string
+		want string // "" when the rule isn't understood
+	}{
+		{"daily from the due date", "daily", "2026-10-14", "2026-10-14", "2026-10-15"},
+		{"daily catches up when late", "daily", "2026-10-10", "2026-10-14", "2026-10-15"},
+		{"weekly keeps the weekday", "weekly", "2026-10-07", "2026-10-14", "2026-10-21"},
+		{"weekly done early", "weekly", "2026-10-14", "2026-10-12", "2026-10-21"},
+		{"weekly on a named day", "weekly:mon", "", "2026-10-14", "2026-10-19"},
+		{"weekly on today's weekday", "weekly:wed", "", "2026-10-14", "2026-10-21"},
+		{"monthly keeps the day", "monthly", "2026-10-15", "2026-10-15", "2026-11-15"},
+		{"monthly clamps short months", "monthly", "2026-01-31", "2026-01-31", "2026-02-28"},
+		{"monthly on a day", "monthly:31", "2026-01-31", "2026-02-10", "2026-02-28"},
+		{"monthly on a day still ahead", "monthly:20", "", "2026-10-14", "2026-10-20"},
+		{"yearly", "yearly", "2026-02-28", "2026-03-01", "2027-02-28"},
+		{"relative to completion", "+2w", "2026-10-01", "2026-10-14", "2026-10-28"},
+		{"relative without a due date", "+3d", "", "2026-10-14", "2026-10-17"},
+		{"case and spaces", " Weekly:MON ", "", "2026-10-14", "2026-10-19"},
+		{"unknown weekday", "weekly:funday", "", "2026-10-14", ""},
+		{"zero period", "+0d", "", "2026-10-14", ""},
+		{"day out of range", "monthly:32", "", "2026-10-14", ""},
+		{"unknown unit", "+2q", "", "2026-10-14", ""},
+		{"unknown rule", "hourly", "", "2026-10-14", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var due *time.Time
+			if tt.due != "" {
+				due = day(tt.due)
+			}
+			got, ok := NextDue(tt.rule, due, day(tt.done).Add(17*time.Hour))
+			if tt.want == "" {
+				if ok {
+					t.Fatalf("NextDue(%q) = %s, want the rule rejected", tt.rule, got.Format(DateLayout))
+				}
+				if ValidRecurrence(tt.rule) {
+					t.Errorf("ValidRecurrence(%q) = true", tt.rule)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("NextDue(%q) rejected the rule, want %s", tt.rule, tt.want)
+			}
+			if got.Format(DateLayout) != tt.want {
+				t.Errorf("NextDue(%q, %s, %s) = %s, want %s", tt.rule, tt.due, tt.done, got.Format(DateLayout), tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"slices"
 	"strings"
 	"time"
 )
@@ -14,15 +15,70 @@ const (
 )
 
 type Task struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Done        bool      `json:"done"`
-	CreatedAt   time.Time `json:"created_at"`
-	Priority    Priority  `json:"priority"`
-	Project     string    `json:"project"` // e.g., "atlas"
-	Contexts    []string  `json:"contexts"` // e.g., "@home", "@work"
-	Category    string    `json:"category"`
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Done        bool       `json:"done"`
+	Status      string     `json:"status,omitempty"` // Board column, e.g. "doing"; see CurrentStatus
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"` // In the trash since
+	Orphaned    []string   `json:"orphaned,omitempty"`   // Subtasks the delete moved up a level
+	Priority    Priority   `json:"priority"`
+	Project     string     `json:"project"`  // e.g., "atlas"
+	Contexts    []string   `json:"contexts"` // e.g., "home", "work"
+	Category    string     `json:"category"`
+	Due         *time.Time `json:"due,omitempty"`
+	Scheduled   *time.Time `json:"scheduled,omitempty"` // Day planned to work on it
+	Recur       string     `json:"recur,omitempty"` // e.g., "weekly:mon", "+2w"
+	ParentID    string     `json:"parent_id,omitempty"`
+	Collapsed   bool       `json:"collapsed,omitempty"` // Subtasks hidden in the TUI
+	Order       float64    `json:"order,omitempty"`     // Manual rank, lowest first
+}
+
+// Board statuses. Any other name can be used as a custom column; done is
+// always the last one and mirrors Done.
+const (
+	StatusTodo    = "todo"
+	StatusDoing   = "doing"
+	StatusBlocked = "blocked"
+	StatusDone    = "done"
+)
+
+// CurrentStatus returns the task's board column: done when it's done,
+// todo when it has never been put anywhere.
+func (t Task) CurrentStatus() string {
+	switch {
+	case t.Done:
+		return StatusDone
+	case t.Status == "" || t.Status == StatusDone:
+		return StatusTodo
+	}
+	return t.Status
+}
+
+// String returns the priority's name as typed after '!', e.g. "high".
+func (p Priority) String() string {
+	switch p {
+	case PriorityHigh:
+		return "high"
+	case PriorityLow:
+		return "low"
+	}
+	return "med"
+}
+
+// ParsePriority reads a priority name: high, med (or medium) or low.
+func ParsePriority(s string) (Priority, bool) {
+	switch strings.ToLower(strings.TrimPrefix(s, "!")) {
+	case "high":
+		return PriorityHigh, true
+	case "med", "medium":
+		return PriorityMedium, true
+	case "low":
+		return PriorityLow, true
+	}
+	return PriorityMedium, false
 }
 
 func NewTask(title string) Task {
@@ -49,18 +105,47 @@ func ParseTask(input string) Task {
 		input = strings.ReplaceAll(input, "!low", "")
 	}
 
-	// 2. Category (Single - First one wins, others removed to keep title clean)
+	// 2. Category (Single - First one wins, any further @words become contexts)
 	words := strings.Fields(input)
 	cleanWords := []string{}
 	foundCategory := false
-	
+
 	for _, w := range words {
+		if strings.HasPrefix(w, "due:") {
+			if d, ok := ParseDate(strings.TrimPrefix(w, "due:"), t.CreatedAt); ok {
+				t.Due = &d
+				continue
+			}
+		}
+
+		if strings.HasPrefix(w, "sched:") {
+			if d, ok := ParseDate(strings.TrimPrefix(w, "sched:"), t.CreatedAt); ok {
+				t.Scheduled = &d
+				continue
+			}
+		}
+
+		if strings.HasPrefix(w, "rec:") {
+			if rule := strings.ToLower(strings.TrimPrefix(w, "rec:")); ValidRecurrence(rule) {
+				t.Recur = rule
+				continue
+			}
+		}
+
 		if strings.HasPrefix(w, "@") && len(w) > 1 {
+			name := strings.TrimPrefix(w, "@")
 			if !foundCategory {
-				t.Category = strings.TrimPrefix(w, "@")
+				t.Category = name
 				foundCategory = true
+			} else if name != t.Category && !slices.Contains(t.Contexts, name) {
+				t.Contexts = append(t.Contexts, name)
 			}
 			// We skip appending this word to cleanWords, effectively removing it from title
+		} else if isProject(w) {
+			// 3. Project (Single - First one wins)
+			if t.Project == "" {
+				t.Project = strings.TrimPrefix(w, "+")
+			}
 		} else {
 			cleanWords = append(cleanWords, w)
 		}
@@ -70,17 +155,48 @@ func ParseTask(input string) Task {
 	return t
 }
 
+// Reparse replaces everything ParseTask reads from text (title, priority,
+// category, contexts, project, dates and recurrence) with what input
+// says, keeping the task's ID, state and place in the tree.
+func (t Task) Reparse(input string) Task {
+	p := ParseTask(input)
+	t.Title = p.Title
+	t.Category = p.Category
+	t.Project = p.Project
+	t.Contexts = p.Contexts
+	t.Priority = p.Priority
+	t.Due = p.Due
+	t.Scheduled = p.Scheduled
+	t.Recur = p.Recur
+	return t
+}
+
 func (t Task) Format() string {
 	var parts []string
 	parts = append(parts, t.Title)
+	if t.Project != "" {
+		parts = append(parts, "+"+t.Project)
+	}
 	if t.Category != "" {
 		parts = append(parts, "@"+t.Category)
 	}
+	for _, c := range t.Contexts {
+		parts = append(parts, "@"+c)
+	}
+	if t.Due != nil {
+		parts = append(parts, "due:"+t.Due.Format(DateLayout))
+	}
+	if t.Scheduled != nil {
+		parts = append(parts, "sched:"+t.Scheduled.Format(DateLayout))
+	}
+	if t.Recur != "" {
+		parts = append(parts, "rec:"+t.Recur)
+	}
 	switch t.Priority {
 	case PriorityHigh:
 		parts = append(parts, "!high")
 	case PriorityMedium:
-		// parts = append(parts, "!med") // Med is default, maybe don't include it if it's default? 
+		// parts = append(parts, "!med") // Med is default, maybe don't include it if it's default?
 		// Actually, let's include it for clarity if we want to be explicit.
 		// But if it was parsed without it, maybe we should skip it.
 		// Let's include it if it's not the default to keep it simple.
@@ -89,3 +205,27 @@ func (t Task) Format() string {
 	}
 	return strings.Join(parts, " ")
 }
+
+// IsOverdue reports whether an open task's due date lies before today.
+func (t Task) IsOverdue(now time.Time) bool {
+	return !t.Done && t.Due != nil && t.Due.Before(StartOfDay(now))
+}
+
+// Day is the day a task is planned for: its scheduled date, or the day it
+// was created if it has none.
+func (t Task) Day() time.Time {
+	if t.Scheduled != nil {
+		return StartOfDay(t.Scheduled.Local())
+	}
+	return StartOfDay(t.CreatedAt.Local())
+}
+
+// isProject reports whether w is a +project token. The name must start with
+// a letter so "+1" or "+3d" stay part of the title.
+func isProject(w string) bool {
+	if len(w) < 2 || w[0] != '+' {
+		return false
+	}
+	c := w[1]
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseTask(t *testing.T) {
+	tests := []struct {
+		input    string
+		title    string
+		priority Priority
+		category string
+		contexts []string
+		project  string
+	}{
+		{"Buy milk", "Buy milk", PriorityMedium, "", nil, ""},
+		{"Buy milk @store !high", "Buy milk", PriorityHigh, "store", nil, ""},
+		{"Call @phone @work @home @work", "Call", PriorityMedium, "phone", []string{"work", "home"}, ""},
+		{"Write docs +atlas +other", "Write docs", PriorityMedium, "", nil, "atlas"},
+		{"Plan +1 and +3d", "Plan +1 and +3d", PriorityMedium, "", nil, ""},
+		{"Email @ and + !low", "Email @ and +", PriorityLow, "", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := ParseTask(tt.input)
+			if got.Title != tt.title || got.Priority != tt.priority || got.Category != tt.category ||
+				!slices.Equal(got.Contexts, tt.contexts) || got.Project != tt.project {
+				t.Errorf("ParseTask(%q) = %q %v @%q %q +%q; want %q %v @%q %q +%q", tt.input,
+					got.Title, got.Priority, got.Category, got.Contexts, got.Project,
+					tt.title, tt.priority, tt.category, tt.contexts, tt.project)
+			}
+		})
+	}
+}
+
+// TestFormat checks that Format writes what ParseTask reads back, as
+// editing a task in the TUI relies on.
+func TestFormat(t *testing.T) {
+	for _, input := range []string{
+		"Buy milk",
+		"Buy milk @store @errands +house !high",
+		"Sweep !low",
+	} {
+		want := ParseTask(input)
+		got := ParseTask(want.Format())
+		if got.Title != want.Title || got.Priority != want.Priority || got.Category != want.Category ||
+			!slices.Equal(got.Contexts, want.Contexts) || got.Project != want.Project {
+			t.Errorf("%q formats as %q, which reads back differently", input, want.Format())
+		}
+	}
+}
@@ -0,0 +1,372 @@
+// Package query is the filter language shared by 'atlas.todo list' and the
+// TUI search prompt, e.g.
+//
+//	cat:work prio:>=med due:<7d -done text:"deploy" +atlas @home created:this-week
+//
+// A query is a list of terms separated by spaces, and a task matches when
+// every term does. A leading - negates a term. Plain words match the title
+// or description, ignoring case.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+// Error is a parse error at a byte offset in the query, so it can be
+// pointed at where the query is shown.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (at column %d)", e.Msg, e.Pos+1)
+}
+
+// Query is a parsed query. The zero value matches every task.
+type Query struct {
+	terms []term
+}
+
+type term struct {
+	match  func(model.Task) bool
+	negate bool
+	status bool // Looks at Done, e.g. "done" or "is:open"
+}
+
+// Match reports whether the task matches every term.
+func (q Query) Match(t model.Task) bool {
+	for _, tm := range q.terms {
+		if tm.match(t) == tm.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// Empty reports whether the query has no terms.
+func (q Query) Empty() bool {
+	return len(q.terms) == 0
+}
+
+// MentionsDone reports whether the query filters on completion, in which
+// case callers shouldn't also hide done tasks on their own.
+func (q Query) MentionsDone() bool {
+	for _, tm := range q.terms {
+		if tm.status {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse reads a query. Relative dates such as due:<7d or
+// created:this-week are resolved against now.
+func Parse(s string, now time.Time) (Query, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return Query{}, err
+	}
+
+	var q Query
+	for _, tok := range tokens {
+		tm, err := parseTerm(tok, now)
+		if err != nil {
+			return Query{}, err
+		}
+		q.terms = append(q.terms, tm)
+	}
+	return q, nil
+}
+
+type token struct {
+	text string
+	pos  int
+}
+
+// tokenize splits on spaces outside double quotes. Quotes stay in the
+// token text, so a quoted word can be told apart from a keyword.
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	start, quote := -1, -1
+	for i, r := range s {
+		switch {
+		case r == '"':
+			if start < 0 {
+				start = i
+			}
+			if quote < 0 {
+				quote = i
+			} else {
+				quote = -1
+			}
+		case (r == ' ' || r == '\t') && quote < 0:
+			if start >= 0 {
+				tokens = append(tokens, token{s[start:i], start})
+				start = -1
+			}
+		default:
+			if start < 0 {
+				start = i
+			}
+		}
+	}
+	if quote >= 0 {
+		return nil, &Error{quote, "unterminated quote"}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{s[start:], start})
+	}
+	return tokens, nil
+}
+
+func parseTerm(tok token, now time.Time) (term, error) {
+	s, pos := tok.text, tok.pos
+	var tm term
+	if strings.HasPrefix(s, "-") {
+		if len(s) == 1 {
+			return tm, &Error{pos, "nothing to exclude after -"}
+		}
+		tm.negate = true
+		s, pos = s[1:], pos+1
+	}
+
+	var err error
+	switch {
+	case strings.HasPrefix(s, `"`):
+		tm.match = textMatch(unquote(s), true)
+		return tm, nil
+	case s == "done":
+		tm.match, tm.status = isDone, true
+		return tm, nil
+	case len(s) > 1 && s[0] == '@':
+		tm.match = tagMatch(unquote(s[1:]))
+		return tm, nil
+	case len(s) > 1 && s[0] == '+' && isLetter(s[1]):
+		tm.match = projectMatch(unquote(s[1:]))
+		return tm, nil
+	case len(s) > 1 && s[0] == '!':
+		tm.match, err = priorityMatch(s[1:], pos+1)
+		return tm, err
+	}
+
+	key, value, ok := strings.Cut(s, ":")
+	if !ok || key == "" {
+		tm.match = textMatch(unquote(s), true)
+		return tm, nil
+	}
+	value = unquote(value)
+	vpos := pos + len(key) + 1
+	if value == "" {
+		return tm, &Error{vpos, fmt.Sprintf("%s: needs a value", key)}
+	}
+
+	switch strings.ToLower(key) {
+	case "text":
+		tm.match = textMatch(value, true)
+	case "title":
+		tm.match = textMatch(value, false)
+	case "cat", "category":
+		tm.match = func(t model.Task) bool { return strings.EqualFold(t.Category, value) }
+	case "ctx", "context":
+		tm.match = func(t model.Task) bool { return containsFold(t.Contexts, value) }
+	case "project":
+		tm.match = projectMatch(value)
+	case "prio", "priority":
+		tm.match, err = priorityMatch(value, vpos)
+	case "due":
+		tm.match, err = dateMatch(value, vpos, now, func(t model.Task) *time.Time { return t.Due })
+	case "created":
+		tm.match, err = dateMatch(value, vpos, now, func(t model.Task) *time.Time { return &t.CreatedAt })
+	case "sched", "scheduled":
+		tm.match, err = dateMatch(value, vpos, now, func(t model.Task) *time.Time { return t.Scheduled })
+	case "completed":
+		tm.match, err = dateMatch(value, vpos, now, func(t model.Task) *time.Time { return t.CompletedAt })
+	case "status":
+		value = strings.ToLower(value)
+		tm.match, tm.status = func(t model.Task) bool { return t.CurrentStatus() == value }, true
+	case "rec", "recur":
+		tm.match = func(t model.Task) bool { return strings.EqualFold(t.Recur, value) }
+	case "id":
+		tm.match = func(t model.Task) bool { return strings.HasPrefix(strings.ToUpper(t.ID), strings.ToUpper(value)) }
+	case "is":
+		tm.match, tm.status, err = isMatch(value, vpos, now)
+	default:
+		return tm, &Error{pos, fmt.Sprintf("unknown field %q (quote it to search for the text)", key)}
+	}
+	return tm, err
+}
+
+func isMatch(value string, pos int, now time.Time) (func(model.Task) bool, bool, error) {
+	switch strings.ToLower(value) {
+	case "done":
+		return isDone, true, nil
+	case "open":
+		return func(t model.Task) bool { return !t.Done }, true, nil
+	case "overdue":
+		return func(t model.Task) bool { return t.IsOverdue(now) }, true, nil
+	case "recurring":
+		return func(t model.Task) bool { return t.Recur != "" }, false, nil
+	case "subtask":
+		return func(t model.Task) bool { return t.ParentID != "" }, false, nil
+	}
+	return nil, false, &Error{pos, fmt.Sprintf("unknown is:%s (use done, open, overdue, recurring or subtask)", value)}
+}
+
+func isDone(t model.Task) bool {
+	return t.Done
+}
+
+func textMatch(text string, description bool) func(model.Task) bool {
+	text = strings.ToLower(text)
+	return func(t model.Task) bool {
+		return strings.Contains(strings.ToLower(t.Title), text) ||
+			description && strings.Contains(strings.ToLower(t.Description), text)
+	}
+}
+
+// tagMatch matches @name against the category or any context.
+func tagMatch(name string) func(model.Task) bool {
+	return func(t model.Task) bool {
+		return strings.EqualFold(t.Category, name) || containsFold(t.Contexts, name)
+	}
+}
+
+func projectMatch(name string) func(model.Task) bool {
+	return func(t model.Task) bool { return strings.EqualFold(t.Project, name) }
+}
+
+func priorityMatch(value string, pos int) (func(model.Task) bool, error) {
+	op, value := splitOp(value)
+	p, ok := model.ParsePriority(value)
+	if !ok {
+		return nil, &Error{pos + len(op), fmt.Sprintf("unknown priority %q (use high, med or low)", value)}
+	}
+	return func(t model.Task) bool {
+		switch op {
+		case "<":
+			return t.Priority < p
+		case "<=":
+			return t.Priority <= p
+		case ">":
+			return t.Priority > p
+		case ">=":
+			return t.Priority >= p
+		case "!=":
+			return t.Priority != p
+		}
+		return t.Priority == p
+	}, nil
+}
+
+// dateMatch compares a date field with a day or span. "none" and "any"
+// match tasks without and with the date.
+func dateMatch(value string, pos int, now time.Time, get func(model.Task) *time.Time) (func(model.Task) bool, error) {
+	switch strings.ToLower(value) {
+	case "none":
+		return func(t model.Task) bool { return get(t) == nil }, nil
+	case "any":
+		return func(t model.Task) bool { return get(t) != nil }, nil
+	}
+
+	op, value := splitOp(value)
+	from, to, ok := span(value, now)
+	if !ok {
+		return nil, &Error{pos + len(op), fmt.Sprintf("can't read %q as a date", value)}
+	}
+	return func(t model.Task) bool {
+		d := get(t)
+		if d == nil {
+			return false
+		}
+		switch op {
+		case "<":
+			return d.Before(from)
+		case "<=":
+			return d.Before(to)
+		case ">":
+			return !d.Before(to)
+		case ">=":
+			return !d.Before(from)
+		case "!=":
+			return d.Before(from) || !d.Before(to)
+		}
+		return !d.Before(from) && d.Before(to)
+	}, nil
+}
+
+// span resolves a date expression to the days it covers, [from, to). It
+// takes anything model.ParseDate does, bare offsets (7d for +7d, -7d for a
+// week ago) and the periods this-week, last-week, next-week and the same
+// for month and year. Weeks start on Monday.
+func span(s string, now time.Time) (from, to time.Time, ok bool) {
+	s = strings.ToLower(s)
+	today := model.StartOfDay(now)
+
+	if rel, period, found := strings.Cut(s, "-"); found {
+		shift := map[string]int{"last": -1, "this": 0, "next": 1}
+		if n, known := shift[rel]; known {
+			switch period {
+			case "week":
+				from = today.AddDate(0, 0, -((int(today.Weekday())+6)%7)+7*n)
+				return from, from.AddDate(0, 0, 7), true
+			case "month":
+				from = time.Date(today.Year(), today.Month()+time.Month(n), 1, 0, 0, 0, 0, today.Location())
+				return from, from.AddDate(0, 1, 0), true
+			case "year":
+				from = time.Date(today.Year()+n, time.January, 1, 0, 0, 0, 0, today.Location())
+				return from, from.AddDate(1, 0, 0), true
+			}
+		}
+	}
+
+	if len(s) > 1 && (s[0] == '-' || s[0] >= '0' && s[0] <= '9') && !strings.Contains(s[1:], "-") {
+		sign := 1
+		if s[0] == '-' {
+			sign, s = -1, s[1:]
+		}
+		// The whole count has to be digits: not 1.5d, 3xd or -+3d
+		if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && s[0] != '+' {
+			if d, ok := model.AddPeriod(today, sign*n, s[len(s)-1]); ok {
+				return d, d.AddDate(0, 0, 1), true
+			}
+		}
+		return from, to, false
+	}
+
+	d, ok := model.ParseDate(s, now)
+	return d, d.AddDate(0, 0, 1), ok
+}
+
+// splitOp splits a comparison operator off the front of a value. No
+// operator (or =) means equal.
+func splitOp(value string) (string, string) {
+	for _, op := range []string{"<=", ">=", "!=", "<", ">", "="} {
+		if strings.HasPrefix(value, op) {
+			return op, value[len(op):]
+		}
+	}
+	return "", value
+}
+
+func unquote(s string) string {
+	return strings.ReplaceAll(s, `"`, "")
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
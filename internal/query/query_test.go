@@ -0,0 +1,165 @@
+package query
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+// A Wednesday afternoon
+var now = time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)
+
+func date(s string) *time.Time {
+	d, _ := time.ParseInLocation(model.DateLayout, s, time.UTC)
+	return &d
+}
+
+var tasks = []model.Task{
+	{
+		ID: "01AAAA", Title: "Deploy the API", Description: "Needs the new certs",
+		Priority: model.PriorityHigh, Category: "work", Contexts: []string{"laptop"}, Project: "atlas",
+		Due: date("2026-10-13"), CreatedAt: *date("2026-10-12"),
+	},
+	{
+		ID: "01BBBB", Title: "Buy milk", Priority: model.PriorityLow, Category: "home",
+		Due: date("2026-10-16"), Scheduled: date("2026-10-15"), CreatedAt: *date("2026-10-01"),
+	},
+	{
+		ID: "01CCCC", Title: "Write release notes", Priority: model.PriorityMedium, Project: "atlas",
+		Done: true, CompletedAt: date("2026-10-14"), CreatedAt: *date("2026-10-13"), Status: model.StatusDone,
+	},
+	{
+		ID: "01DDDD", Title: "Water plants", Priority: model.PriorityMedium, Recur: "weekly",
+		Status: model.StatusDoing, ParentID: "01BBBB", CreatedAt: *date("2026-10-14"),
+	},
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string // IDs of the matching tasks
+	}{
+		{"", []string{"01AAAA", "01BBBB", "01CCCC", "01DDDD"}},
+		{"milk", []string{"01BBBB"}},
+		{"MILK", []string{"01BBBB"}},
+		{"certs", []string{"01AAAA"}},
+		{"title:certs", nil},
+		{`"release notes"`, []string{"01CCCC"}},
+		{`"due:"`, nil},
+		{"@work", []string{"01AAAA"}},
+		{"@laptop", []string{"01AAAA"}},
+		{"cat:laptop", nil},
+		{"ctx:laptop", []string{"01AAAA"}},
+		{"+atlas", []string{"01AAAA", "01CCCC"}},
+		{"+atlas -done", []string{"01AAAA"}},
+		{"!high", []string{"01AAAA"}},
+		{"prio:>=med", []string{"01AAAA", "01CCCC", "01DDDD"}},
+		{"prio:<med", []string{"01BBBB"}},
+		{"prio:!=med", []string{"01AAAA", "01BBBB"}},
+		{"due:today", nil},
+		{"due:<today", []string{"01AAAA"}},
+		{"due:<7d", []string{"01AAAA", "01BBBB"}},
+		{"due:>=today", []string{"01BBBB"}},
+		{"due:none", []string{"01CCCC", "01DDDD"}},
+		{"due:any", []string{"01AAAA", "01BBBB"}},
+		{"due:fri", []string{"01BBBB"}},
+		{"sched:tomorrow", []string{"01BBBB"}},
+		{"created:this-week", []string{"01AAAA", "01CCCC", "01DDDD"}},
+		{"created:last-week", nil},
+		{"created:this-month", []string{"01AAAA", "01BBBB", "01CCCC", "01DDDD"}},
+		{"completed:today", []string{"01CCCC"}},
+		{"done", []string{"01CCCC"}},
+		{"-done", []string{"01AAAA", "01BBBB", "01DDDD"}},
+		{"is:open", []string{"01AAAA", "01BBBB", "01DDDD"}},
+		{"is:overdue", []string{"01AAAA"}},
+		{"is:recurring", []string{"01DDDD"}},
+		{"is:subtask", []string{"01DDDD"}},
+		{"status:doing", []string{"01DDDD"}},
+		{"status:todo", []string{"01AAAA", "01BBBB"}},
+		{"rec:weekly", []string{"01DDDD"}},
+		{"id:01b", []string{"01BBBB"}},
+		{"+atlas !high @work due:<today", []string{"01AAAA"}},
+		{"-@work -@home", []string{"01CCCC", "01DDDD"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Parse(tt.query, now)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.query, err)
+			}
+			var got []string
+			for _, task := range tasks {
+				if q.Match(task) {
+					got = append(got, task.ID)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("%q matched %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMentionsDone(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"milk", false},
+		{"done", true},
+		{"-done", true},
+		{"is:open", true},
+		{"status:doing", true},
+		{"is:recurring", false},
+		{"@work !high", false},
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.query, now)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.query, err)
+		}
+		if q.MentionsDone() != tt.want {
+			t.Errorf("Parse(%q).MentionsDone() = %v, want %v", tt.query, !tt.want, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int // Byte offset the error points at
+	}{
+		{`"unterminated`, 0},
+		{`milk "unterminated`, 5},
+		{"-", 0},
+		{"milk -", 5},
+		{"due:someday", 4},
+		{"milk due:<someday", 10},
+		{"due:<1.5d", 5},
+		{"due:<3xd", 5},
+		{"due:-+3d", 4},
+		{"prio:urgent", 5},
+		{"prio:>=urgent", 7},
+		{"!urgent", 1},
+		{"-!urgent", 2},
+		{"cat:", 4},
+		{"is:nope", 3},
+		{"milk bogus:1", 5},
+		{"-bogus:1", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(tt.query, now)
+			var qe *Error
+			if !errors.As(err, &qe) {
+				t.Fatalf("Parse(%q) error = %v, want a *query.Error", tt.query, err)
+			}
+			if qe.Pos != tt.pos {
+				t.Errorf("Parse(%q) error at %d (%s), want %d", tt.query, qe.Pos, qe.Msg, tt.pos)
+			}
+		})
+	}
+}
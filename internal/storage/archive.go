@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+// archiveSuffix marks archive files, e.g. lists/work.archive.json.
+const archiveSuffix = ".archive"
+
+// ArchivePath returns where the archive for the task file at path lives:
+// archive.json next to todo.json, <name>.archive.json next to any other.
+func ArchivePath(path string) string {
+	dir, base := filepath.Split(path)
+	if base == "todo.json" {
+		return filepath.Join(dir, "archive.json")
+	}
+	return filepath.Join(dir, strings.TrimSuffix(base, ".json")+archiveSuffix+".json")
+}
+
+// OpenArchive returns the loaded archive for the task file at path. It is
+// an ordinary task file, so it gets the same locking, journal and backups.
+func OpenArchive(path string) (*JSONStore, error) {
+	archive, err := NewJSONStore(ArchivePath(path))
+	if err != nil {
+		return nil, err
+	}
+	if err := archive.Load(); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// CompletedAt is when a done task was finished. Tasks completed before
+// completion times were recorded fall back to when they were created, so
+// for them it's only an approximation, good enough to order and archive
+// by but not to say what happened on a given day.
+func CompletedAt(t model.Task) time.Time {
+	if t.CompletedAt != nil {
+		return *t.CompletedAt
+	}
+	return t.CreatedAt
+}
+
+// Archive moves the tasks completed before cutoff from store into archive
+// and returns them. A done task with open subtasks stays put; otherwise
+// its subtasks go along with it. The archive is saved first, so a crash
+// in between leaves a task in both places rather than neither.
+func Archive(store, archive Backend, cutoff time.Time) ([]model.Task, error) {
+	var ids []string
+	seen := map[string]bool{}
+	finished := store.Query(func(t model.Task) bool {
+		return t.Done && CompletedAt(t).Before(cutoff)
+	})
+	for _, t := range finished {
+		if seen[t.ID] {
+			continue
+		}
+		subtree := store.Descendants(t.ID)
+		open := false
+		for _, sub := range subtree {
+			open = open || !sub.Done
+		}
+		if open {
+			continue
+		}
+		for _, task := range append([]model.Task{t}, subtree...) {
+			if !seen[task.ID] {
+				seen[task.ID] = true
+				ids = append(ids, task.ID)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	for _, id := range ids {
+		t, _ := store.Get(id)
+		archive.Add(t)
+	}
+	if err := archive.Save(); err != nil {
+		return nil, err
+	}
+	moved := store.Remove("archive", ids...)
+	return moved, store.Save()
+}
+
+// Unarchive moves tasks back from archive into store. A task whose parent
+// isn't in the store any more comes back top-level. The store is saved
+// first, for the same reason as in Archive.
+func Unarchive(store, archive Backend, ids ...string) ([]model.Task, error) {
+	var restored []model.Task
+	for _, id := range ids {
+		t, ok := archive.Get(id)
+		if !ok {
+			continue
+		}
+		if _, ok := store.Get(t.ParentID); !ok && !slices.Contains(ids, t.ParentID) {
+			t.ParentID = ""
+		}
+		restored = append(restored, store.Add(t))
+	}
+	if len(restored) == 0 {
+		return nil, nil
+	}
+	if err := store.Save(); err != nil {
+		return nil, err
+	}
+	archive.Remove("restore", ids...)
+	return restored, archive.Save()
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+func TestArchivePath(t *testing.T) {
+	tests := map[string]string{
+		"/home/me/.atlas/todo.json":           "/home/me/.atlas/archive.json",
+		"/home/me/.atlas/lists/work.json":     "/home/me/.atlas/lists/work.archive.json",
+		"/src/project/.atlas-todo.json":       "/src/project/.atlas-todo.archive.json",
+		"/home/me/.atlas/lists/notes.v2.json": "/home/me/.atlas/lists/notes.v2.archive.json",
+	}
+	for path, want := range tests {
+		if got := ArchivePath(path); got != want {
+			t.Errorf("ArchivePath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestArchive(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local)
+	old, recent := now.AddDate(0, 0, -10), now.AddDate(0, 0, -1)
+	store := NewMemoryStore(
+		model.Task{ID: "a", Title: "old", Done: true, CompletedAt: &old},
+		model.Task{ID: "b", Title: "recent", Done: true, CompletedAt: &recent},
+		model.Task{ID: "c", Title: "old with open subtask", Done: true, CompletedAt: &old},
+		model.Task{ID: "c1", Title: "open subtask", ParentID: "c"},
+		model.Task{ID: "d", Title: "old with done subtask", Done: true, CompletedAt: &old},
+		model.Task{ID: "d1", Title: "done subtask", Done: true, CompletedAt: &recent, ParentID: "d"},
+		model.Task{ID: "e", Title: "open"},
+		model.Task{ID: "f", Title: "old without a completion time", Done: true, CreatedAt: old},
+	)
+	archive := NewMemoryStore()
+
+	moved, err := Archive(store, archive, now.AddDate(0, 0, -7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := ids(moved), []string{"a", "d", "d1", "f"}; !slices.Equal(got, want) {
+		t.Errorf("archived %q, want %q", got, want)
+	}
+	if got, want := ids(store.Query(nil)), []string{"b", "c", "c1", "e"}; !slices.Equal(got, want) {
+		t.Errorf("store keeps %q, want %q", got, want)
+	}
+	if got := ids(archive.Query(nil)); !slices.Equal(got, ids(moved)) {
+		t.Errorf("archive holds %q, want %q", got, ids(moved))
+	}
+
+	// The parent stays archived, so the subtask comes back top-level
+	restored, err := Unarchive(store, archive, "d1", "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(restored) != 1 || restored[0].ID != "d1" || restored[0].ParentID != "" {
+		t.Errorf("restored %+v, want d1 without a parent", restored)
+	}
+	if got, want := ids(archive.Query(nil)), []string{"a", "d", "f"}; !slices.Equal(got, want) {
+		t.Errorf("archive holds %q after the restore, want %q", got, want)
+	}
+}
+
+// ids lists the tasks' IDs in order.
+func ids(tasks []model.Task) []string {
+	var out []string
+	for _, t := range tasks {
+		out = append(out, t.ID)
+	}
+	return out
+}
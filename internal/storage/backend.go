@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"atlas.todo/internal/model"
+)
+
+type Config struct {
+	ShowDone   bool   `json:"show_done"`
+	SortByDate bool   `json:"sort_by_date"`
+	SortAsc    bool   `json:"sort_asc"`
+	Grouping   int    `json:"grouping"`
+	Views      []View `json:"views,omitempty"`
+	// Days deleted tasks stay in the trash: 0 for the default, < 0 to
+	// keep them forever
+	TrashDays int `json:"trash_days,omitempty"`
+	// Board columns, DefaultColumns if empty
+	Columns []string `json:"columns,omitempty"`
+}
+
+// View is a named set of view settings, e.g. "Today" or "Work high prio":
+// a search query plus the same flags as Config.
+type View struct {
+	Name       string `json:"name"`
+	Query      string `json:"query,omitempty"`
+	ShowDone   bool   `json:"show_done"`
+	SortByDate bool   `json:"sort_by_date"`
+	SortAsc    bool   `json:"sort_asc"`
+	Grouping   int    `json:"grouping"`
+}
+
+// View returns the saved view with this name, ignoring case.
+func (c Config) View(name string) (View, bool) {
+	for _, v := range c.Views {
+		if strings.EqualFold(v.Name, name) {
+			return v, true
+		}
+	}
+	return View{}, false
+}
+
+// SetView saves v, replacing the view with the same name if there is one.
+func (c *Config) SetView(v View) {
+	c.Views = slices.Clone(c.Views)
+	for i := range c.Views {
+		if strings.EqualFold(c.Views[i].Name, v.Name) {
+			c.Views[i] = v
+			return
+		}
+	}
+	c.Views = append(c.Views, v)
+}
+
+// DeleteView removes the saved view with this name and reports whether
+// there was one.
+func (c *Config) DeleteView(name string) bool {
+	n := len(c.Views)
+	c.Views = slices.DeleteFunc(slices.Clone(c.Views), func(v View) bool {
+		return strings.EqualFold(v.Name, name)
+	})
+	if len(c.Views) == 0 {
+		c.Views = nil
+	}
+	return len(c.Views) < n
+}
+
+func (c Config) equal(o Config) bool {
+	return reflect.DeepEqual(c, o)
+}
+
+// Backend is where tasks live. The TUI and CLI only talk to this interface,
+// so new storage can be added without touching them. Tasks are handed out
+// as copies; changes go back through Update and the other mutators, and are
+// persisted by Save.
+type Backend interface {
+	// Load reads the stored tasks, replacing what's in memory.
+	Load() error
+	// Save persists the in-memory tasks.
+	Save() error
+	// Refresh picks up changes made by someone else since the last Load or
+	// Save and reports whether there were any.
+	Refresh() (bool, error)
+
+	// Query returns the tasks match accepts, in their manual order (see
+	// Move). A nil match returns every task. Tasks in the trash are left
+	// out here and by every other method but the trash ones.
+	Query(match func(model.Task) bool) []model.Task
+	// Get returns the task with exactly this ID.
+	Get(id string) (model.Task, bool)
+	// Find returns the task whose ID is ref or uniquely starts with it.
+	Find(ref string) (model.Task, error)
+	// ShortIDs maps every task ID to its shortest unique prefix.
+	ShortIDs() map[string]string
+	// Descendants returns every task nested below id, at any depth.
+	Descendants(id string) []model.Task
+
+	// Add stores a new task, assigning an ID if it has none, and returns it.
+	Add(t model.Task) model.Task
+	// Update replaces the stored task with the same ID.
+	Update(t model.Task) error
+	// Delete moves a task to the trash. Its subtasks move up a level.
+	Delete(id string) error
+	// Trash returns the deleted tasks, most recently deleted first.
+	Trash() []model.Task
+	// FindDeleted is Find for the tasks in the trash.
+	FindDeleted(ref string) (model.Task, error)
+	// Undelete takes a task back out of the trash.
+	Undelete(id string) error
+	// Remove takes tasks out outright, e.g. into the archive or when the
+	// trash is purged, recording action in the history, and returns them.
+	Remove(action string, ids ...string) []model.Task
+	// Toggle flips a task's completion. Completing a recurring task spawns
+	// its next instance, which is returned.
+	Toggle(id string) (*model.Task, error)
+	// SetParent nests a task under parentID, or makes it top-level when
+	// parentID is empty.
+	SetParent(id, parentID string) error
+
+	Config() Config
+	SetConfig(c Config)
+
+	// OpenArchive returns the loaded store that finished tasks are archived
+	// to (see Archive).
+	OpenArchive() (Backend, error)
+
+	// Undo reverts the most recent saved change and describes it; Redo
+	// re-applies the most recently undone one. Both need a Save afterwards.
+	Undo() (string, error)
+	Redo() (string, error)
+	// History returns the recorded events touching a task, or all events
+	// when id is empty, oldest first.
+	History(id string) []Event
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	// BackupCount is how many rolling backups (todo.json.1 … todo.json.N)
+	// are kept next to the task file.
+	BackupCount = 5
+	// BackupInterval is the minimum age of the newest backup before a save
+	// rotates in a new one, so a burst of TUI edits doesn't flush out the
+	// whole history.
+	BackupInterval = 10 * time.Minute
+)
+
+type Backup struct {
+	N       int
+	Path    string
+	ModTime time.Time
+	Tasks   int
+}
+
+func (s *JSONStore) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", s.filePath, n)
+}
+
+// rotateBackups shifts todo.json.N-1 → .N … .1 → .2 and copies the current
+// file to .1. Unless force is set it does nothing while the newest backup
+// is younger than BackupInterval.
+func (s *JSONStore) rotateBackups(force bool) error {
+	current, err := os.ReadFile(s.filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(s.backupPath(1)); err == nil && !force {
+		if time.Since(info.ModTime()) < BackupInterval {
+			return nil
+		}
+	}
+
+	for n := BackupCount - 1; n >= 1; n-- {
+		err := os.Rename(s.backupPath(n), s.backupPath(n+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return writeFileAtomic(s.backupPath(1), current, 0644)
+}
+
+// Backups lists the available backups, newest first.
+func (s *JSONStore) Backups() ([]Backup, error) {
+	var backups []Backup
+	for n := 1; n <= BackupCount; n++ {
+		path := s.backupPath(n)
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		b := Backup{N: n, Path: path, ModTime: info.ModTime(), Tasks: -1}
+		if data, err := os.ReadFile(path); err == nil {
+			if sd, err := decode(data); err == nil {
+				b.Tasks = len(sd.Tasks)
+			}
+		}
+		backups = append(backups, b)
+	}
+	return backups, nil
+}
+
+// Restore rolls the tasks back to backup n and saves. The state being
+// replaced is rotated into the backups first, and the restore is journaled
+// like any other change, so it can itself be undone.
+func (s *JSONStore) Restore(n int) error {
+	if n < 1 || n > BackupCount {
+		return fmt.Errorf("no backup #%d (backups are numbered 1-%d)", n, BackupCount)
+	}
+
+	data, err := os.ReadFile(s.backupPath(n))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("no backup #%d", n)
+	}
+	if err != nil {
+		return err
+	}
+	fd, err := decode(data)
+	if err != nil {
+		return fmt.Errorf("backup #%d is unreadable: %w", n, err)
+	}
+
+	s.mu.Lock()
+	unlock, err := lockFile(s.filePath + ".lock")
+	if err != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("failed to lock tasks: %w", err)
+	}
+	err = s.rotateBackups(true)
+	unlock()
+	if err == nil {
+		s.replace("restore", fd.Tasks, fd.Config)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return s.Save()
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+func TestBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.json")
+	store := open(t, path)
+	store.Add(model.Task{Title: "first"})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if backups, _ := store.Backups(); len(backups) != 0 {
+		t.Fatalf("%d backup(s) of a new file, want none", len(backups))
+	}
+
+	store.Add(model.Task{Title: "second"})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	// Saving again straight away doesn't push the first backup out
+	store.Add(model.Task{Title: "third"})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	backups, err := store.Backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 1 || backups[0].N != 1 || backups[0].Tasks != 1 {
+		t.Fatalf("backups %+v, want #1 holding the first task", backups)
+	}
+
+	if err := store.Restore(1); err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(open(t, path)); !slices.Equal(got, []string{"first"}) {
+		t.Errorf("restored %q, want [first]", got)
+	}
+	// The state the restore replaced is kept as the newest backup
+	if backups, _ := store.Backups(); len(backups) != 2 || backups[0].Tasks != 3 {
+		t.Errorf("backups after the restore %+v, want #1 holding all three tasks", backups)
+	}
+	if err := store.Restore(BackupCount + 1); err == nil {
+		t.Error("restored a backup that can't exist")
+	}
+}
+
+func TestBackupRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.json")
+	store := open(t, path)
+	for i := 0; i < BackupCount+3; i++ {
+		store.Add(model.Task{Title: "task"})
+		if err := store.Save(); err != nil {
+			t.Fatal(err)
+		}
+		// Age the newest backup past the interval
+		old := time.Now().Add(-2 * BackupInterval)
+		if err := os.Chtimes(path+".1", old, old); err != nil && !os.IsNotExist(err) {
+			t.Fatal(err)
+		}
+	}
+	backups, err := store.Backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != BackupCount {
+		t.Fatalf("%d backups, want %d", len(backups), BackupCount)
+	}
+	for i, b := range backups {
+		// Newest first: the save before last held one task fewer
+		if want := BackupCount + 2 - i; b.Tasks != want {
+			t.Errorf("backup #%d holds %d task(s), want %d", b.N, b.Tasks, want)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+var ErrCycle = errors.New("a task can't be nested under its own subtask")
+
+// core holds tasks and config in memory and implements the Backend
+// operations every store shares. Stores embed it and add persistence.
+type core struct {
+	mu     sync.Mutex
+	tasks  []model.Task
+	config Config
+
+	// Every change is recorded as an event. pending holds the ones made
+	// since the last Save; history the ones already committed.
+	history []Event
+	pending []Event
+	lastSeq int64
+}
+
+func (s *core) Query(match func(model.Task) bool) []model.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]model.Task, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		if t.DeletedAt == nil && (match == nil || match(t)) {
+			out = append(out, clone(t))
+		}
+	}
+	slices.SortStableFunc(out, byOrder)
+	return out
+}
+
+func (s *core) Get(id string) (model.Task, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i := s.live(id); i >= 0 {
+		return clone(s.tasks[i]), true
+	}
+	return model.Task{}, false
+}
+
+func (s *core) Add(t model.Task) model.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t.ID == "" {
+		t.ID = model.NewID()
+	}
+	if t.Order == 0 {
+		// New tasks go to the bottom of the manual order
+		for _, other := range s.tasks {
+			t.Order = max(t.Order, other.Order)
+		}
+		t.Order++
+	}
+	s.put("add", t)
+	return t
+}
+
+func (s *core) Update(t model.Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.live(t.ID) < 0 {
+		return fmt.Errorf("%w: %q", ErrNotFound, t.ID)
+	}
+	s.put("edit", t)
+	return nil
+}
+
+// Toggle flips the completion state of a task and stamps or clears its
+// CompletedAt. Completing a recurring task spawns its next instance, which
+// takes over the rule, and returns it.
+func (s *core) Toggle(id string) (*model.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.live(id)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+
+	now := time.Now()
+	t := clone(s.tasks[i])
+	t.Done = !t.Done
+	t.CompletedAt = &now
+	t.Status = model.StatusDone
+	action := "done"
+	if !t.Done {
+		action = "reopen"
+		t.CompletedAt = nil
+		t.Status = ""
+	}
+	if !t.Done || t.Recur == "" {
+		s.put(action, t)
+		return nil, nil
+	}
+
+	due, ok := model.NextDue(t.Recur, t.Due, now)
+	if !ok {
+		s.put(action, t)
+		return nil, nil
+	}
+
+	next := clone(t)
+	next.ID = model.NewID()
+	next.Done = false
+	next.Status = ""
+	next.CompletedAt = nil
+	next.CreatedAt = now
+	if t.Scheduled != nil && t.Due != nil {
+		// Keep the same lead time before the new due date
+		days := int(math.Round(due.Sub(*t.Due).Hours() / 24))
+		scheduled := t.Scheduled.AddDate(0, 0, days)
+		next.Scheduled = &scheduled
+	} else {
+		next.Scheduled = nil
+	}
+	next.Due = &due
+	t.Recur = ""
+
+	s.put(action, t)
+	s.put("recur", next)
+	return &next, nil
+}
+
+// Delete moves a task to the trash, where it stays hidden until it's
+// restored or purged. Its subtasks are not lost: they move up a level and
+// are adopted by the deleted task's parent until Undelete takes them back.
+func (s *core) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.live(id)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+
+	removed := clone(s.tasks[i])
+	now := time.Now()
+	removed.DeletedAt = &now
+	var moved []model.Task
+	for _, t := range s.tasks {
+		if t.ParentID == removed.ID {
+			t = clone(t)
+			t.ParentID = removed.ParentID
+			moved = append(moved, t)
+			removed.Orphaned = append(removed.Orphaned, t.ID)
+		}
+	}
+	s.put("delete", removed)
+	for _, t := range moved {
+		s.put("move", t)
+	}
+	return nil
+}
+
+// Trash returns the deleted tasks, most recently deleted first.
+func (s *core) Trash() []model.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []model.Task
+	for _, t := range s.tasks {
+		if t.DeletedAt != nil {
+			out = append(out, clone(t))
+		}
+	}
+	slices.SortStableFunc(out, func(a, b model.Task) int {
+		return b.DeletedAt.Compare(*a.DeletedAt)
+	})
+	return out
+}
+
+// Undelete takes a task back out of the trash. It comes back top-level if
+// its parent is gone, and takes back the subtasks Delete moved up a level,
+// except any that have been moved or deleted since.
+func (s *core) Undelete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.index(id)
+	if i < 0 || s.tasks[i].DeletedAt == nil {
+		return fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+
+	t := clone(s.tasks[i])
+	adoptedBy := t.ParentID
+	t.DeletedAt = nil
+	orphaned := t.Orphaned
+	t.Orphaned = nil
+	if s.live(t.ParentID) < 0 {
+		t.ParentID = ""
+	}
+	s.put("restore", t)
+	for _, childID := range orphaned {
+		if j := s.live(childID); j >= 0 && s.tasks[j].ParentID == adoptedBy {
+			child := clone(s.tasks[j])
+			child.ParentID = t.ID
+			s.put("move", child)
+		}
+	}
+	return nil
+}
+
+// SetParent nests a task under parentID, or makes it top-level when
+// parentID is empty. Moves that would create a cycle are refused.
+func (s *core) SetParent(id, parentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.live(id)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+
+	for p := parentID; p != ""; {
+		if p == id {
+			return ErrCycle
+		}
+		j := s.live(p)
+		if j < 0 {
+			return fmt.Errorf("%w: %q", ErrNotFound, p)
+		}
+		p = s.tasks[j].ParentID
+	}
+
+	t := clone(s.tasks[i])
+	t.ParentID = parentID
+	s.put("move", t)
+	return nil
+}
+
+// Remove takes tasks out of the store outright, recording action in the
+// history, and returns them. Unlike Delete, subtasks stay where they are.
+// IDs that aren't in the store are skipped.
+func (s *core) Remove(action string, ids ...string) []model.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []model.Task
+	for _, id := range ids {
+		if i := s.index(id); i >= 0 {
+			out = append(out, clone(s.tasks[i]))
+			s.remove(action, id)
+		}
+	}
+	return out
+}
+
+// Descendants returns every task nested below id, at any depth.
+func (s *core) Descendants(id string) []model.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []model.Task
+	queue := []string{id}
+	seen := map[string]bool{id: true}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, t := range s.tasks {
+			if t.ParentID == parent && t.DeletedAt == nil && !seen[t.ID] {
+				seen[t.ID] = true
+				out = append(out, clone(t))
+				queue = append(queue, t.ID)
+			}
+		}
+	}
+	return out
+}
+
+func (s *core) Config() Config {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.config
+	c.Views = slices.Clone(c.Views)
+	c.Columns = slices.Clone(c.Columns)
+	return c
+}
+
+func (s *core) SetConfig(c Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c.Views = slices.Clone(c.Views)
+	c.Columns = slices.Clone(c.Columns)
+	s.putConfig("config", c)
+}
+
+func (s *core) index(id string) int {
+	for i, t := range s.tasks {
+		if t.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// live is index for tasks that aren't in the trash.
+func (s *core) live(id string) int {
+	if i := s.index(id); i >= 0 && s.tasks[i].DeletedAt == nil {
+		return i
+	}
+	return -1
+}
+
+// clone copies a task so callers can't reach into the store through its
+// slices and pointers.
+func clone(t model.Task) model.Task {
+	t.Contexts = slices.Clone(t.Contexts)
+	t.Orphaned = slices.Clone(t.Orphaned)
+	t.Due = clonePtr(t.Due)
+	t.Scheduled = clonePtr(t.Scheduled)
+	t.CompletedAt = clonePtr(t.CompletedAt)
+	t.DeletedAt = clonePtr(t.DeletedAt)
+	return t
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
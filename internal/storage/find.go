@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"atlas.todo/internal/model"
+)
+
+// MinShortID is the shortest prefix ShortIDs hands out, so short IDs stay
+// recognisable as IDs and don't go stale the moment one more task is added.
+const MinShortID = 6
+
+var (
+	ErrNotFound  = errors.New("no task with that ID")
+	ErrAmbiguous = errors.New("ID prefix matches more than one task")
+)
+
+// Find returns the task whose ID is ref, or uniquely starts with ref.
+// Matching ignores case, since IDs are case-insensitive base32.
+func (s *core) Find(ref string) (model.Task, error) {
+	return s.find(ref, false)
+}
+
+// FindDeleted is Find for the tasks in the trash.
+func (s *core) FindDeleted(ref string) (model.Task, error) {
+	return s.find(ref, true)
+}
+
+func (s *core) find(ref string, deleted bool) (model.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ref = strings.ToUpper(strings.TrimSpace(ref))
+	if ref == "" {
+		return model.Task{}, ErrNotFound
+	}
+
+	match := -1
+	for i, t := range s.tasks {
+		if (t.DeletedAt != nil) != deleted {
+			continue
+		}
+		id := strings.ToUpper(t.ID)
+		if id == ref {
+			return clone(t), nil
+		}
+		if strings.HasPrefix(id, ref) {
+			if match >= 0 {
+				return model.Task{}, fmt.Errorf("%w: %q", ErrAmbiguous, ref)
+			}
+			match = i
+		}
+	}
+	if match < 0 {
+		return model.Task{}, fmt.Errorf("%w: %q", ErrNotFound, ref)
+	}
+	return clone(s.tasks[match]), nil
+}
+
+// ShortIDs maps every task ID to its shortest unique prefix (at least
+// MinShortID characters), for display. Tasks in the trash count too, so a
+// short ID stays valid when a task is deleted or restored.
+func (s *core) ShortIDs() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, len(s.tasks))
+	for i, t := range s.tasks {
+		ids[i] = strings.ToUpper(t.ID)
+	}
+	sort.Strings(ids)
+
+	short := make(map[string]string, len(ids))
+	for i, id := range ids {
+		n := MinShortID
+		if i > 0 {
+			n = max(n, commonPrefix(id, ids[i-1])+1)
+		}
+		if i < len(ids)-1 {
+			n = max(n, commonPrefix(id, ids[i+1])+1)
+		}
+		short[id] = id[:min(n, len(id))]
+	}
+
+	out := make(map[string]string, len(s.tasks))
+	for _, t := range s.tasks {
+		out[t.ID] = short[strings.ToUpper(t.ID)]
+	}
+	return out
+}
+
+func commonPrefix(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
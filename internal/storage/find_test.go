@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"atlas.todo/internal/model"
+)
+
+func TestFind(t *testing.T) {
+	store := NewMemoryStore(
+		model.Task{ID: "01ABCDEF0000", Title: "first"},
+		model.Task{ID: "01ABCDEG0000", Title: "second"},
+		model.Task{ID: "01ZZZZZZ0000", Title: "third"},
+		model.Task{ID: "01ZZZZZZ0000-1", Title: "migrated duplicate"},
+	)
+
+	tests := []struct {
+		ref   string
+		want  string // Title found, if any
+		error error
+	}{
+		{"01ABCDEF0000", "first", nil},
+		{"01ABCDEF", "first", nil},
+		{"01abcdeg", "second", nil},
+		{" 01ABCDEG ", "second", nil},
+		{"01Z", "", ErrAmbiguous},
+		{"01ZZZZZZ0000", "third", nil}, // Exact beats a longer prefix match
+		{"01ZZZZZZ0000-", "migrated duplicate", nil},
+		{"01ABCDE", "", ErrAmbiguous},
+		{"01X", "", ErrNotFound},
+		{"", "", ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := store.Find(tt.ref)
+			if tt.error != nil {
+				if !errors.Is(err, tt.error) {
+					t.Fatalf("Find(%q) error = %v, want %v", tt.ref, err, tt.error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Find(%q): %v", tt.ref, err)
+			}
+			if got.Title != tt.want {
+				t.Errorf("Find(%q) = %q, want %q", tt.ref, got.Title, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindDeleted(t *testing.T) {
+	store := NewMemoryStore(
+		model.Task{ID: "01ABCDEF0000", Title: "kept"},
+		model.Task{ID: "01ABCDEG0000", Title: "deleted"},
+	)
+	if err := store.Delete("01ABCDEG0000"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The prefix is unique among the live tasks and among the deleted ones
+	if got, err := store.Find("01ABCDE"); err != nil || got.Title != "kept" {
+		t.Errorf("Find = %q, %v; want the live task", got.Title, err)
+	}
+	if got, err := store.FindDeleted("01ABCDE"); err != nil || got.Title != "deleted" {
+		t.Errorf("FindDeleted = %q, %v; want the deleted task", got.Title, err)
+	}
+	if _, err := store.Find("01ABCDEG"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Find of a deleted task: %v, want ErrNotFound", err)
+	}
+}
+
+func TestShortIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []string
+		want map[string]string
+	}{
+		{
+			name: "at least MinShortID",
+			ids:  []string{"01ABCDEF0000", "01ZZZZZZ0000"},
+			want: map[string]string{"01ABCDEF0000": "01ABCD", "01ZZZZZZ0000": "01ZZZZ"},
+		},
+		{
+			name: "long enough to be unique",
+			ids:  []string{"01ABCDEF0000", "01ABCDEG0000", "01ZZZZZZ0000"},
+			want: map[string]string{"01ABCDEF0000": "01ABCDEF", "01ABCDEG0000": "01ABCDEG", "01ZZZZZZ0000": "01ZZZZ"},
+		},
+		{
+			name: "case-insensitive",
+			ids:  []string{"01abcdef0000", "01ABCDEG0000"},
+			want: map[string]string{"01abcdef0000": "01ABCDEF", "01ABCDEG0000": "01ABCDEG"},
+		},
+		{
+			name: "a prefix of another ID",
+			ids:  []string{"20260101", "20260101-1"},
+			want: map[string]string{"20260101": "20260101", "20260101-1": "20260101-"},
+		},
+		{
+			name: "shorter than MinShortID",
+			ids:  []string{"1", "2"},
+			want: map[string]string{"1": "1", "2": "2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tasks []model.Task
+			for _, id := range tt.ids {
+				tasks = append(tasks, model.Task{ID: id})
+			}
+			got := NewMemoryStore(tasks...).ShortIDs()
+			for id, want := range tt.want {
+				if got[id] != want {
+					t.Errorf("ShortIDs()[%q] = %q, want %q", id, got[id], want)
+				}
+			}
+		})
+	}
+}
+
+func TestShortIDsCountTheTrash(t *testing.T) {
+	store := NewMemoryStore(model.Task{ID: "01ABCDEF0000"}, model.Task{ID: "01ABCDEG0000"})
+	before := store.ShortIDs()["01ABCDEF0000"]
+	if err := store.Delete("01ABCDEG0000"); err != nil {
+		t.Fatal(err)
+	}
+	if after := store.ShortIDs()["01ABCDEF0000"]; after != before {
+		t.Errorf("short ID changed from %q to %q when a task was deleted", before, after)
+	}
+}
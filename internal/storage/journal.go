@@ -0,0 +1,296 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+var (
+	ErrNothingToUndo = errors.New("nothing to undo")
+	ErrNothingToRedo = errors.New("nothing to redo")
+	ErrUnsaved       = errors.New("save pending changes first")
+)
+
+// Event is one change to the store: a task added (Before is nil), updated,
+// or deleted (After is nil), or the config replaced. All events written by
+// a single Save form a batch, which is what undo and redo work on.
+type Event struct {
+	Seq    int64       `json:"seq"`
+	Batch  int64       `json:"batch"`
+	Time   time.Time   `json:"time"`
+	Action string      `json:"action"` // e.g., "add", "done", "edit", "delete", "config"
+	TaskID string      `json:"task_id,omitempty"`
+	Before *model.Task `json:"before,omitempty"`
+	After  *model.Task `json:"after,omitempty"`
+
+	ConfigBefore *Config `json:"config_before,omitempty"`
+	ConfigAfter  *Config `json:"config_after,omitempty"`
+
+	Undoes int64 `json:"undoes,omitempty"` // Batch reverted by this one
+	Redoes int64 `json:"redoes,omitempty"` // Batch re-applied by this one
+}
+
+// Describe summarises an event for history listings.
+func (e Event) Describe() string {
+	title := ""
+	switch {
+	case e.After != nil:
+		title = e.After.Title
+	case e.Before != nil:
+		title = e.Before.Title
+	}
+
+	switch {
+	case e.Undoes != 0:
+		return fmt.Sprintf("undo: %s", title)
+	case e.Redoes != 0:
+		return fmt.Sprintf("redo: %s", title)
+	case e.ConfigAfter != nil:
+		return "settings changed"
+	case e.Action == "edit" && e.Before != nil && e.After != nil &&
+		e.Before.Title != e.After.Title:
+		return fmt.Sprintf("edit: %s → %s", e.Before.Title, e.After.Title)
+	}
+	return fmt.Sprintf("%s: %s", e.Action, title)
+}
+
+// put adds or replaces a task and records the change. Callers hold s.mu.
+func (s *core) put(action string, t model.Task) {
+	e := Event{Action: action, TaskID: t.ID, After: ptr(clone(t))}
+	if i := s.index(t.ID); i >= 0 {
+		e.Before = ptr(clone(s.tasks[i]))
+	}
+	s.record(e)
+}
+
+// remove deletes a task and records the change. Callers hold s.mu.
+func (s *core) remove(action string, id string) {
+	if i := s.index(id); i >= 0 {
+		s.record(Event{Action: action, TaskID: id, Before: ptr(clone(s.tasks[i]))})
+	}
+}
+
+// putConfig replaces the config and records the change, if there is one.
+// Callers hold s.mu.
+func (s *core) putConfig(action string, c Config) {
+	if c.equal(s.config) {
+		return
+	}
+	before := s.config
+	s.record(Event{Action: action, ConfigBefore: &before, ConfigAfter: &c})
+}
+
+func (s *core) record(e Event) {
+	e.Time = time.Now()
+	s.apply(e, false)
+	s.pending = append(s.pending, e)
+}
+
+// apply makes the store match an event's outcome, or its starting point
+// when inverse is set, without recording anything.
+func (s *core) apply(e Event, inverse bool) {
+	before, after := e.Before, e.After
+	cfg := e.ConfigAfter
+	if inverse {
+		before, after = after, before
+		cfg = e.ConfigBefore
+	}
+
+	if cfg != nil {
+		s.config = *cfg
+	}
+	if e.TaskID == "" {
+		return
+	}
+
+	i := s.index(e.TaskID)
+	switch {
+	case after == nil && i >= 0:
+		s.tasks = append(s.tasks[:i], s.tasks[i+1:]...)
+	case after != nil && i >= 0:
+		s.tasks[i] = clone(*after)
+	case after != nil:
+		s.tasks = append(s.tasks, clone(*after))
+	}
+}
+
+// commit numbers the pending events as one batch and moves them into the
+// history. Callers hold s.mu.
+func (s *core) commit() []Event {
+	if len(s.pending) == 0 {
+		return nil
+	}
+	batch := s.lastSeq + 1
+	for i := range s.pending {
+		s.lastSeq++
+		s.pending[i].Seq = s.lastSeq
+		s.pending[i].Batch = batch
+	}
+	committed := s.pending
+	s.history = append(s.history, committed...)
+	s.pending = nil
+	return committed
+}
+
+// stacks replays the history's undo and redo bookkeeping and returns the
+// batches that can currently be undone and redone, most recent last.
+func (s *core) stacks() (undo, redo []int64) {
+	drop := func(stack []int64, b int64) []int64 {
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i] == b {
+				return append(stack[:i], stack[i+1:]...)
+			}
+		}
+		return stack
+	}
+
+	var last int64
+	for _, e := range s.history {
+		if e.Batch == last {
+			continue
+		}
+		last = e.Batch
+		switch {
+		case e.Undoes != 0:
+			undo = drop(undo, e.Undoes)
+			redo = append(redo, e.Undoes)
+		case e.Redoes != 0:
+			redo = drop(redo, e.Redoes)
+			undo = append(undo, e.Redoes)
+		default:
+			undo = append(undo, e.Batch)
+			redo = nil
+		}
+	}
+	return undo, redo
+}
+
+func (s *core) batch(b int64) []Event {
+	var events []Event
+	for _, e := range s.history {
+		if e.Batch == b && e.Undoes == 0 && e.Redoes == 0 {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// Undo reverts the most recent saved change that hasn't been undone yet and
+// describes it. The revert is itself recorded, so it can be redone.
+func (s *core) Undo() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.pending) > 0 {
+		return "", ErrUnsaved
+	}
+	undo, _ := s.stacks()
+	if len(undo) == 0 {
+		return "", ErrNothingToUndo
+	}
+
+	b := undo[len(undo)-1]
+	events := s.batch(b)
+	for i := len(events) - 1; i >= 0; i-- {
+		s.revert(events[i], true, b)
+	}
+	return summarize(events), nil
+}
+
+// Redo re-applies the most recently undone change and describes it.
+func (s *core) Redo() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.pending) > 0 {
+		return "", ErrUnsaved
+	}
+	_, redo := s.stacks()
+	if len(redo) == 0 {
+		return "", ErrNothingToRedo
+	}
+
+	b := redo[len(redo)-1]
+	events := s.batch(b)
+	for _, e := range events {
+		s.revert(e, false, b)
+	}
+	return summarize(events), nil
+}
+
+// revert records an event that takes the store back to e's starting point
+// (undo) or forward to its outcome again (redo).
+func (s *core) revert(e Event, undo bool, b int64) {
+	target, cfg := e.After, e.ConfigAfter
+	if undo {
+		target, cfg = e.Before, e.ConfigBefore
+	}
+
+	out := Event{Action: e.Action, TaskID: e.TaskID, After: target}
+	if undo {
+		out.Undoes = b
+	} else {
+		out.Redoes = b
+	}
+	if cfg != nil {
+		before := s.config
+		out.ConfigBefore, out.ConfigAfter = &before, cfg
+	}
+	if i := s.index(e.TaskID); e.TaskID != "" && i >= 0 {
+		out.Before = ptr(clone(s.tasks[i]))
+	}
+	s.record(out)
+}
+
+// History returns every recorded event that touched the task, or all events
+// when id is empty, oldest first.
+func (s *core) History(id string) []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []Event
+	for _, e := range slices.Concat(s.history, s.pending) {
+		if id == "" || e.TaskID == id {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// replace makes the store match the given tasks and config, recording each
+// difference so the whole replacement can be undone. Callers hold s.mu.
+func (s *core) replace(action string, tasks []model.Task, cfg *Config) {
+	keep := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		keep[t.ID] = true
+	}
+	for _, t := range slices.Clone(s.tasks) {
+		if !keep[t.ID] {
+			s.remove(action, t.ID)
+		}
+	}
+	for _, t := range tasks {
+		if i := s.index(t.ID); i < 0 || !reflect.DeepEqual(s.tasks[i], t) {
+			s.put(action, t)
+		}
+	}
+	if cfg != nil {
+		s.putConfig(action, *cfg)
+	}
+}
+
+func summarize(events []Event) string {
+	if len(events) == 1 {
+		return events[0].Describe()
+	}
+	return fmt.Sprintf("%s (+%d more changes)", events[0].Describe(), len(events)-1)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// JournalLimit is how many events the journal may hold before it's
+	// compacted down to the most recent JournalKeep. The task file is the
+	// snapshot replay starts from, so only undo history is lost.
+	JournalLimit = 10000
+	JournalKeep  = 5000
+)
+
+// journalPath is the journal next to the task file: todo.json → todo.journal.
+func (s *JSONStore) journalPath() string {
+	return strings.TrimSuffix(s.filePath, ".json") + ".journal"
+}
+
+// syncJournal reads events other processes appended since we last looked
+// and adds them to the history. Callers hold s.mu.
+func (s *JSONStore) syncJournal() error {
+	f, err := os.Open(s.journalPath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if s.journalFile != nil && !os.SameFile(info, s.journalFile) || info.Size() < s.journalOffset {
+		// Compacted by someone else, which replaces the file: start over.
+		// The new one can be as long as what we'd read of the old one, so
+		// the size alone doesn't tell.
+		s.journalOffset = 0
+		s.history = nil
+	}
+	s.journalFile = info
+	if info.Size() == s.journalOffset {
+		return nil
+	}
+
+	if _, err := f.Seek(s.journalOffset, io.SeekStart); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	// A crash mid-append can leave a partial last line; leave it be
+	end := bytes.LastIndexByte(data, '\n') + 1
+
+	sc := bufio.NewScanner(bytes.NewReader(data[:end]))
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return fmt.Errorf("corrupt journal entry: %w", err)
+		}
+		s.history = append(s.history, e)
+		s.lastSeq = max(s.lastSeq, e.Seq)
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	s.journalOffset += int64(end)
+	return nil
+}
+
+// appendJournal writes committed events to the end of the journal and
+// flushes them to disk. Callers hold s.mu and the file lock.
+func (s *JSONStore) appendJournal(events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	f, err := os.OpenFile(s.journalPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	s.journalFile = info
+	if err := f.Close(); err != nil {
+		return err
+	}
+	s.journalOffset += int64(buf.Len())
+	return nil
+}
+
+// compactJournal drops the oldest events once the journal outgrows
+// JournalLimit, cutting on a batch boundary. Callers hold s.mu and the
+// file lock.
+func (s *JSONStore) compactJournal() error {
+	if len(s.history) <= JournalLimit {
+		return nil
+	}
+
+	cut := len(s.history) - JournalKeep
+	for cut < len(s.history) && cut > 0 && s.history[cut].Batch == s.history[cut-1].Batch {
+		cut++
+	}
+	kept := append([]Event(nil), s.history[cut:]...)
+
+	var buf bytes.Buffer
+	for _, e := range kept {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if err := writeFileAtomic(s.journalPath(), buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	info, err := os.Stat(s.journalPath())
+	if err != nil {
+		return err
+	}
+	s.history = kept
+	s.journalFile = info
+	s.journalOffset = int64(buf.Len())
+	return nil
+}
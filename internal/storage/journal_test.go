@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"atlas.todo/internal/model"
+)
+
+func TestUndoRedo(t *testing.T) {
+	// Each step acts on a store holding one task, "a", added and saved
+	type step struct {
+		do   func(s *MemoryStore) error
+		want []string // Titles afterwards
+		err  error
+	}
+	undo := func(s *MemoryStore) error { _, err := s.Undo(); return err }
+	redo := func(s *MemoryStore) error { _, err := s.Redo(); return err }
+	saved := func(do func(s *MemoryStore) error) func(s *MemoryStore) error {
+		return func(s *MemoryStore) error {
+			if err := do(s); err != nil {
+				return err
+			}
+			return s.Save()
+		}
+	}
+	rename := func(title string) func(s *MemoryStore) error {
+		return func(s *MemoryStore) error {
+			t := s.Query(nil)[0]
+			t.Title = title
+			return s.Update(t)
+		}
+	}
+	add := func(title string) func(s *MemoryStore) error {
+		return func(s *MemoryStore) error { s.Add(model.Task{Title: title}); return nil }
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"undo an add", []step{
+			{saved(undo), nil, nil},
+			{undo, nil, ErrNothingToUndo},
+		}},
+		{"undo then redo", []step{
+			{saved(rename("b")), []string{"b"}, nil},
+			{saved(undo), []string{"a"}, nil},
+			{saved(redo), []string{"b"}, nil},
+			{redo, []string{"b"}, ErrNothingToRedo},
+		}},
+		{"undo in order", []step{
+			{saved(rename("b")), []string{"b"}, nil},
+			{saved(rename("c")), []string{"c"}, nil},
+			{saved(undo), []string{"b"}, nil},
+			{saved(undo), []string{"a"}, nil},
+			{saved(redo), []string{"b"}, nil},
+			{saved(redo), []string{"c"}, nil},
+		}},
+		{"a new change clears redo", []step{
+			{saved(rename("b")), []string{"b"}, nil},
+			{saved(undo), []string{"a"}, nil},
+			{saved(add("x")), []string{"a", "x"}, nil},
+			{redo, []string{"a", "x"}, ErrNothingToRedo},
+		}},
+		{"a save is undone as one", []step{
+			{saved(func(s *MemoryStore) error { add("x")(s); add("y")(s); return nil }), []string{"a", "x", "y"}, nil},
+			{saved(undo), []string{"a"}, nil},
+		}},
+		{"unsaved changes block undo", []step{
+			{rename("b"), []string{"b"}, nil},
+			{undo, []string{"b"}, ErrUnsaved},
+			{redo, []string{"b"}, ErrUnsaved},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore()
+			store.Add(model.Task{Title: "a"})
+			if err := store.Save(); err != nil {
+				t.Fatal(err)
+			}
+			for i, st := range tt.steps {
+				if err := st.do(store); !errors.Is(err, st.err) {
+					t.Fatalf("step %d: error %v, want %v", i+1, err, st.err)
+				}
+				if got := titles(store); !slices.Equal(got, st.want) {
+					t.Fatalf("step %d: tasks %q, want %q", i+1, got, st.want)
+				}
+			}
+		})
+	}
+}
+
+func TestHistory(t *testing.T) {
+	store := NewMemoryStore()
+	a := store.Add(model.Task{Title: "a"})
+	store.Add(model.Task{Title: "b"})
+	store.Save()
+	a.Title = "a2"
+	store.Update(a)
+
+	var got []string
+	for _, e := range store.History(a.ID) {
+		got = append(got, e.Describe())
+	}
+	want := []string{"add: a", "edit: a → a2"}
+	if !slices.Equal(got, want) {
+		t.Errorf("History = %q, want %q", got, want)
+	}
+	if n := len(store.History("")); n != 3 {
+		t.Errorf("History(\"\") has %d events, want 3", n)
+	}
+}
@@ -1,142 +1,351 @@
 package storage
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
 	"time"
 
 	"atlas.todo/internal/model"
 )
 
-type Config struct {
-	ShowDone   bool `json:"show_done"`
-	SortByDate bool `json:"sort_by_date"`
-	SortAsc    bool `json:"sort_asc"`
-	Grouping   int  `json:"grouping"`
+type storeData struct {
+	Tasks      []model.Task `json:"tasks"`
+	Config     Config       `json:"config"`
+	JournalSeq int64        `json:"journal_seq,omitempty"`
+	JournalAt  *time.Time   `json:"journal_at,omitempty"`
 }
 
-type storeData struct {
-	Tasks  []model.Task `json:"tasks"`
-	Config Config       `json:"config"`
+// fileData is a decoded task file. Config is nil for the old array format,
+// which had none.
+type fileData struct {
+	Tasks      []model.Task
+	Config     *Config
+	JournalSeq int64
+	JournalAt  *time.Time
 }
 
-type Store struct {
-	mu       sync.Mutex
-	filePath string
-	Tasks    []model.Task
-	Config   Config
+// JSONStore is the Backend behind a task file such as ~/.atlas/todo.json:
+// a single JSON file holding every task plus the TUI settings. Every
+// change is also appended to a journal next to it (todo.journal), which
+// records the history that undo and 'atlas.todo log' work from. The JSON
+// file is the snapshot: it notes the last journal entry it includes, and
+// Load replays any entries after that, e.g. when a crash hit between the
+// two writes.
+type JSONStore struct {
+	core
+	filePath      string
+	journalOffset int64
+	journalFile   os.FileInfo // The journal we've read up to journalOffset
+
+	// What the file held when we last read or wrote it, used to detect and
+	// merge changes made by other processes.
+	base       map[string]model.Task
+	baseConfig Config
+	hash       [sha256.Size]byte
+	modTime    time.Time
+	size       int64
 }
 
-func NewStore() (*Store, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return nil, err
-	}
+var _ Backend = (*JSONStore)(nil)
 
-	configDir := filepath.Join(home, ".atlas")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+// NewJSONStore returns a store backed by the file at path. The directory
+// is created if needed; the file itself is only written on Save.
+func NewJSONStore(path string) (*JSONStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return nil, err
 	}
 
-	return &Store{
-		filePath: filepath.Join(configDir, "todo.json"),
-		Tasks:    []model.Task{},
-		Config: Config{
-			ShowDone:   false,
-			SortByDate: false,
-			SortAsc:    false,
-			Grouping:   0,
+	return &JSONStore{
+		core: core{
+			tasks: []model.Task{},
+			config: Config{
+				ShowDone:   false,
+				SortByDate: false,
+				SortAsc:    false,
+				Grouping:   0,
+			},
 		},
+		filePath: path,
 	}, nil
 }
 
-func (s *Store) Load() error {
+// Path returns the file the store reads and writes.
+func (s *JSONStore) Path() string {
+	return s.filePath
+}
+
+// OpenArchive returns the archive file next to the task file, loaded.
+func (s *JSONStore) OpenArchive() (Backend, error) {
+	return OpenArchive(s.filePath)
+}
+
+func (s *JSONStore) Load() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	data, err := os.ReadFile(s.filePath)
+	s.history, s.pending, s.lastSeq, s.journalOffset, s.journalFile = nil, nil, 0, 0, nil
+	if err := s.syncJournal(); err != nil {
+		return err
+	}
+
+	data, info, err := s.read()
 	if os.IsNotExist(err) {
+		s.replay(0, nil)
 		return nil // New store, no file yet
 	}
 	if err != nil {
 		return err
 	}
 
+	fd, err := decode(data)
+	if err != nil {
+		return err
+	}
+	s.tasks = fd.Tasks
+	if fd.Config != nil {
+		s.config = *fd.Config
+	}
+	s.remember(data, info)
+	if fd.JournalAt != nil {
+		s.replay(fd.JournalSeq, fd.JournalAt)
+	}
+	return nil
+}
+
+// replay applies the journal entries after the one the snapshot says it
+// includes; they'll be in the snapshot again after the next Save. A
+// snapshot that names an entry this journal doesn't have was written
+// elsewhere (copied, or pulled from git) and is left alone. A nil at
+// replays the whole journal, for when the snapshot is missing.
+func (s *JSONStore) replay(seq int64, at *time.Time) {
+	from := 0
+	if at != nil {
+		from = -1
+		for i, e := range s.history {
+			if e.Seq == seq && e.Time.Equal(*at) {
+				from = i + 1
+			}
+		}
+		if from < 0 {
+			return
+		}
+	}
+	for _, e := range s.history[from:] {
+		s.apply(e, false)
+	}
+}
+
+// Refresh merges in changes another process saved since we last read or
+// wrote the file, and reports whether there were any.
+func (s *JSONStore) Refresh() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.filePath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return false, nil
+	}
+
+	data, info, err := s.read()
+	if err != nil {
+		return false, err
+	}
+	return s.absorb(data, info)
+}
+
+func (s *JSONStore) read() ([]byte, os.FileInfo, error) {
+	f, err := os.Open(s.filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := io.ReadAll(f)
+	return data, info, err
+}
+
+// absorb merges file content into memory if it differs from what we last
+// saw. Local changes that haven't been saved yet are preserved.
+func (s *JSONStore) absorb(data []byte, info os.FileInfo) (bool, error) {
+	if sha256.Sum256(data) == s.hash {
+		s.modTime, s.size = info.ModTime(), info.Size()
+		return false, nil
+	}
+
+	fd, err := decode(data)
+	if err != nil {
+		return false, err
+	}
+	if err := s.syncJournal(); err != nil {
+		return false, err
+	}
+	theirConfig := s.baseConfig
+	if fd.Config != nil {
+		theirConfig = *fd.Config
+	}
+
+	s.tasks = merge(s.base, s.tasks, fd.Tasks)
+	if s.config.equal(s.baseConfig) {
+		s.config = theirConfig
+	}
+
+	// Anything we haven't saved yet is now a change relative to theirs
+	s.base = snapshot(fd.Tasks)
+	s.baseConfig = theirConfig
+	s.hash = sha256.Sum256(data)
+	s.modTime, s.size = info.ModTime(), info.Size()
+	return true, nil
+}
+
+// remember records that the file now holds exactly our in-memory state.
+func (s *JSONStore) remember(data []byte, info os.FileInfo) {
+	s.base = snapshot(s.tasks)
+	s.baseConfig = s.config
+	s.hash = sha256.Sum256(data)
+	s.modTime, s.size = info.ModTime(), info.Size()
+}
+
+// decode parses a task file in either the current object format or the
+// original bare task array. An empty file decodes to an empty store.
+func decode(data []byte) (fileData, error) {
+	var fd fileData
 	trimmed := strings.TrimSpace(string(data))
 	if len(trimmed) == 0 {
-		return nil
+		fd.Tasks = []model.Task{}
+		return fd, nil
 	}
 
 	// Case 1: New format (Object)
 	if trimmed[0] == '{' {
 		var sd storeData
 		if err := json.Unmarshal(data, &sd); err != nil {
-			return fmt.Errorf("failed to parse as object: %w", err)
+			return fd, fmt.Errorf("failed to parse as object: %w", err)
 		}
-		s.Tasks = sd.Tasks
-		s.Config = sd.Config
-		return nil
+		fd.Tasks = dedupeIDs(sd.Tasks)
+		fd.Config = &sd.Config
+		fd.JournalSeq = sd.JournalSeq
+		fd.JournalAt = sd.JournalAt
+		return fd, nil
 	}
 
 	// Case 2: Old format (Array)
 	if trimmed[0] == '[' {
 		var tasks []model.Task
 		if err := json.Unmarshal(data, &tasks); err != nil {
-			return fmt.Errorf("failed to parse as array: %w", err)
+			return fd, fmt.Errorf("failed to parse as array: %w", err)
 		}
-		s.Tasks = tasks
-		return nil
+		fd.Tasks = dedupeIDs(tasks)
+		return fd, nil
 	}
 
-	return fmt.Errorf("unknown file format (must be { or [)")
+	return fd, fmt.Errorf("unknown file format (must be { or [)")
 }
 
-func (s *Store) Save() error {
+// dedupeIDs migrates files written when IDs were second-resolution
+// timestamps and tasks added in the same second shared one. Later copies
+// get a numbered suffix; this is deterministic, so every process sharing
+// the file agrees on the new IDs.
+func dedupeIDs(tasks []model.Task) []model.Task {
+	taken := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		taken[t.ID] = true
+	}
+	claimed := make(map[string]bool, len(tasks))
+	last := map[string]int{} // Highest suffix given to copies of an ID
+	for i, t := range tasks {
+		if !claimed[t.ID] {
+			claimed[t.ID] = true
+			continue
+		}
+		for n := last[t.ID] + 1; ; n++ {
+			id := fmt.Sprintf("%s-%d", t.ID, n)
+			if !taken[id] {
+				last[t.ID] = n
+				taken[id], claimed[id] = true, true
+				tasks[i].ID = id
+				break
+			}
+		}
+	}
+	return tasks
+}
+
+// Save writes the tasks to disk. It holds the file lock while it merges in
+// whatever other processes saved in the meantime and writes the result, so
+// concurrent TUI and CLI sessions never overwrite each other's tasks.
+func (s *JSONStore) Save() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	sd := storeData{
-		Tasks:  s.Tasks,
-		Config: s.Config,
+	unlock, err := lockFile(s.filePath + ".lock")
+	if err != nil {
+		return fmt.Errorf("failed to lock tasks: %w", err)
 	}
+	defer unlock()
 
-	data, err := json.MarshalIndent(sd, "", "  ")
-	if err != nil {
+	data, info, err := s.read()
+	if err == nil {
+		if _, err := s.absorb(data, info); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
 		return err
 	}
 
-	return os.WriteFile(s.filePath, data, 0644)
-}
-
-func (s *Store) Add(t model.Task) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	
-	// Simple ID gen if not present (UUID would be better but keeping deps low for now)
-	if t.ID == "" {
-		t.ID = time.Now().Format("20060102150405")
+	// Journal first: if we crash before the snapshot is written, Load
+	// replays these events
+	if err := s.syncJournal(); err != nil {
+		return err
+	}
+	if err := s.appendJournal(s.commit()); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	if err := s.compactJournal(); err != nil {
+		return fmt.Errorf("failed to compact journal: %w", err)
 	}
-	s.Tasks = append(s.Tasks, t)
-}
 
-func (s *Store) Toggle(index int) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	sd := storeData{
+		Tasks:  s.tasks,
+		Config: s.config,
+	}
+	if n := len(s.history); n > 0 {
+		sd.JournalSeq = s.history[n-1].Seq
+		sd.JournalAt = &s.history[n-1].Time
+	}
 
-	if index >= 0 && index < len(s.Tasks) {
-		s.Tasks[index].Done = !s.Tasks[index].Done
+	data, err = json.MarshalIndent(sd, "", "  ")
+	if err != nil {
+		return err
 	}
-}
 
-func (s *Store) Delete(index int) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	if err := s.rotateBackups(false); err != nil {
+		return fmt.Errorf("failed to back up tasks: %w", err)
+	}
+	if err := writeFileAtomic(s.filePath, data, 0644); err != nil {
+		return err
+	}
 
-	if index >= 0 && index < len(s.Tasks) {
-		s.Tasks = append(s.Tasks[:index], s.Tasks[index+1:]...)
+	info, err = os.Stat(s.filePath)
+	if err != nil {
+		return err
 	}
+	s.remember(data, info)
+	// Best effort: a stale cache only sends the prompt back to the file
+	s.writeSummary(time.Now())
+	return nil
 }
@@ -0,0 +1,226 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"atlas.todo/internal/model"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.json")
+	store := open(t, path)
+	store.Add(model.Task{Title: "first"})
+	store.Add(model.Task{Title: "second"})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(open(t, path)); !slices.Equal(got, []string{"first", "second"}) {
+		t.Errorf("loaded %q, want [first second]", got)
+	}
+}
+
+func TestLoadOldFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.json")
+	write(t, path, `[{"id": "1", "title": "from a bare array"}]`)
+	if got := titles(open(t, path)); !slices.Equal(got, []string{"from a bare array"}) {
+		t.Errorf("loaded %q, want the task in the array", got)
+	}
+}
+
+func TestDedupeIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []string
+		want []string
+	}{
+		{"unique", []string{"a", "b"}, []string{"a", "b"}},
+		{"shared", []string{"a", "a", "b", "a"}, []string{"a", "a-1", "b", "a-2"}},
+		{"suffix already taken", []string{"a", "a", "a-1"}, []string{"a", "a-2", "a-1"}},
+		{"several shared", []string{"a", "b", "a", "b"}, []string{"a", "b", "a-1", "b-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tasks []model.Task
+			for _, id := range tt.ids {
+				tasks = append(tasks, model.Task{ID: id})
+			}
+			var got []string
+			for _, t := range dedupeIDs(tasks) {
+				got = append(got, t.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("dedupeIDs(%q) = %q, want %q", tt.ids, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestJournalReplay saves twice and then rolls the task file back to the
+// first snapshot, as if a crash had hit between the journal append and
+// the snapshot write of the second Save.
+func TestJournalReplay(t *testing.T) {
+	tests := []struct {
+		name  string
+		after func(t *testing.T, path, snapshot string)
+		want  []string
+	}{
+		{
+			name:  "snapshot up to date",
+			after: func(t *testing.T, path, snapshot string) {},
+			want:  []string{"first", "second"},
+		},
+		{
+			name: "snapshot behind the journal",
+			after: func(t *testing.T, path, snapshot string) {
+				write(t, path, snapshot)
+			},
+			want: []string{"first", "second"},
+		},
+		{
+			name: "no snapshot",
+			after: func(t *testing.T, path, snapshot string) {
+				if err := os.Remove(path); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: []string{"first", "second"},
+		},
+		{
+			name: "snapshot from elsewhere",
+			after: func(t *testing.T, path, snapshot string) {
+				write(t, path, snapshot)
+				if err := os.Remove(filepath.Join(filepath.Dir(path), "todo.journal")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: []string{"first"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "todo.json")
+			store := open(t, path)
+			store.Add(model.Task{Title: "first"})
+			if err := store.Save(); err != nil {
+				t.Fatal(err)
+			}
+			snapshot, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			store.Add(model.Task{Title: "second"})
+			if err := store.Save(); err != nil {
+				t.Fatal(err)
+			}
+
+			tt.after(t, path, string(snapshot))
+			if got := titles(open(t, path)); !slices.Equal(got, tt.want) {
+				t.Errorf("loaded %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJournalUndoAcrossProcesses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.json")
+	store := open(t, path)
+	added := store.Add(model.Task{Title: "draft"})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	added.Title = "final"
+	if err := store.Update(added); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another process undoes the edit from the journal
+	other := open(t, path)
+	if _, err := other.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(open(t, path)); !slices.Equal(got, []string{"draft"}) {
+		t.Errorf("after undo: %q, want [draft]", got)
+	}
+}
+
+// TestJournalCompactedElsewhere checks that a store notices another process
+// replacing the journal with a compacted one that isn't any shorter than
+// what the store had read of the old one.
+func TestJournalCompactedElsewhere(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.json")
+	journal := filepath.Join(filepath.Dir(path), "todo.journal")
+	store := open(t, path)
+	store.Add(model.Task{Title: "first"})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	other := open(t, path)
+	for _, title := range []string{"second", "third"} {
+		other.Add(model.Task{Title: title})
+		if err := other.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := os.ReadFile(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	if err := writeFileAtomic(journal, []byte(strings.Join(lines[1:], "")), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if _, err := store.Undo(); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := titles(open(t, path)); !slices.Equal(got, []string{"first"}) {
+		t.Errorf("after undoing twice: %q, want [first]", got)
+	}
+}
+
+func open(t *testing.T, path string) *JSONStore {
+	t.Helper()
+	store, err := NewJSONStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Load(); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func write(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// titles lists the store's tasks' titles in order.
+func titles(store Backend) []string {
+	var out []string
+	for _, t := range store.Query(nil) {
+		out = append(out, t.Title)
+	}
+	return out
+}
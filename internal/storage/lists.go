@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultList is the name of the list kept in todo.json.
+const DefaultList = "default"
+
+var listName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Home returns the data directory: $ATLAS_HOME if set, otherwise ~/.atlas.
+func Home() (string, error) {
+	if dir := os.Getenv("ATLAS_HOME"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".atlas"), nil
+}
+
+// Lists are the named task lists in a data directory. The default list is
+// todo.json (or $ATLAS_TODO_FILE); every other list is lists/<name>.json.
+type Lists struct {
+	Dir string
+}
+
+func NewLists() (*Lists, error) {
+	dir, err := Home()
+	if err != nil {
+		return nil, err
+	}
+	return &Lists{Dir: dir}, nil
+}
+
+// Path returns the file backing a list, which may not exist yet.
+func (l *Lists) Path(name string) (string, error) {
+	if name == "" || name == DefaultList {
+		if file := os.Getenv("ATLAS_TODO_FILE"); file != "" {
+			return file, nil
+		}
+		return filepath.Join(l.Dir, "todo.json"), nil
+	}
+	if !listName.MatchString(name) || strings.HasSuffix(name, archiveSuffix) {
+		return "", fmt.Errorf("invalid list name %q "+
+			"(use letters, digits, '-', '_' and '.')", name)
+	}
+	return filepath.Join(l.Dir, "lists", name+".json"), nil
+}
+
+// Names returns the default list followed by every named list, sorted.
+func (l *Lists) Names() ([]string, error) {
+	names := []string{DefaultList}
+
+	entries, err := os.ReadDir(filepath.Join(l.Dir, "lists"))
+	if os.IsNotExist(err) {
+		return names, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var named []string
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if ok && !e.IsDir() && listName.MatchString(name) && !strings.HasSuffix(name, archiveSuffix) {
+			named = append(named, name)
+		}
+	}
+	sort.Strings(named)
+	return append(names, named...), nil
+}
+
+// Open returns the loaded store for a list. Lists that don't exist yet are
+// created on their first Save.
+func (l *Lists) Open(name string) (Backend, error) {
+	path, err := l.Path(name)
+	if err != nil {
+		return nil, err
+	}
+	store, err := NewJSONStore(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Load(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
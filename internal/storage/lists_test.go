@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestListPath(t *testing.T) {
+	l := &Lists{Dir: "/data"}
+	tests := []struct {
+		name, file string
+		want       string // "" when the name is invalid
+	}{
+		{"", "", "/data/todo.json"},
+		{DefaultList, "", "/data/todo.json"},
+		{"", "/tmp/other.json", "/tmp/other.json"},
+		{"work", "/tmp/other.json", "/data/lists/work.json"},
+		{"side.project_2", "", "/data/lists/side.project_2.json"},
+		{"../escape", "", ""},
+		{".hidden", "", ""},
+		{"a/b", "", ""},
+		{"work.archive", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.file, func(t *testing.T) {
+			t.Setenv("ATLAS_TODO_FILE", tt.file)
+			got, err := l.Path(tt.name)
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("Path(%q) = %q, want an error", tt.name, got)
+				}
+				return
+			}
+			if err != nil || got != filepath.FromSlash(tt.want) {
+				t.Errorf("Path(%q) = %q, %v; want %q", tt.name, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestListNames(t *testing.T) {
+	l := &Lists{Dir: t.TempDir()}
+	if names, err := l.Names(); err != nil || !slices.Equal(names, []string{DefaultList}) {
+		t.Fatalf("Names with no lists = %q, %v; want just the default", names, err)
+	}
+
+	dir := filepath.Join(l.Dir, "lists")
+	if err := os.MkdirAll(filepath.Join(dir, "folder.json"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"work.json", "home.json", "work.archive.json", "notes.txt", ".x.json"} {
+		write(t, filepath.Join(dir, name), "[]")
+	}
+	want := []string{DefaultList, "home", "work"}
+	if names, err := l.Names(); err != nil || !slices.Equal(names, want) {
+		t.Errorf("Names = %q, %v; want %q", names, err, want)
+	}
+}
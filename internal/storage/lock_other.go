@@ -0,0 +1,9 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package storage
+
+// lockFile is a no-op on platforms without advisory locks. Writes are still
+// atomic and merged, only not serialized.
+func lockFile(path string) (func(), error) {
+	return func() {}, nil
+}
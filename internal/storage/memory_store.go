@@ -0,0 +1,43 @@
+package storage
+
+import "atlas.todo/internal/model"
+
+// MemoryStore is a Backend that keeps everything in memory, for tests and
+// throwaway sessions. Load and Refresh do nothing.
+type MemoryStore struct {
+	core
+	archive *MemoryStore
+}
+
+func NewMemoryStore(tasks ...model.Task) *MemoryStore {
+	s := &MemoryStore{}
+	for _, t := range tasks {
+		s.Add(t)
+	}
+	s.pending = nil // The starting tasks aren't undoable changes
+	return s
+}
+
+func (s *MemoryStore) Load() error            { return nil }
+func (s *MemoryStore) Refresh() (bool, error) { return false, nil }
+
+// Save commits the changes made since the last Save to the history, where
+// Undo can find them.
+func (s *MemoryStore) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commit()
+	return nil
+}
+
+// OpenArchive returns an archive that lives in memory alongside the store.
+func (s *MemoryStore) OpenArchive() (Backend, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.archive == nil {
+		s.archive = NewMemoryStore()
+	}
+	return s.archive, nil
+}
+
+var _ Backend = (*MemoryStore)(nil)
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"reflect"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+// merge reconciles our in-memory tasks with theirs, the version another
+// process wrote to disk, using base (the version we last read or wrote) to
+// tell who changed what. It works per task ID:
+//
+//   - a task only we changed, added or deleted keeps our version
+//   - a task only they changed, added or deleted takes theirs
+//   - a task both sides changed keeps ours
+//   - an edit beats a delete, so no one's work is lost
+//
+// Our order is kept, with tasks new on their side appended.
+func merge(base map[string]model.Task, ours, theirs []model.Task) []model.Task {
+	theirIndex := make(map[string]model.Task, len(theirs))
+	for _, t := range theirs {
+		theirIndex[t.ID] = t
+	}
+
+	var out []model.Task
+	seen := map[string]bool{}
+	for _, t := range ours {
+		seen[t.ID] = true
+		b, inBase := base[t.ID]
+		theirT, inTheirs := theirIndex[t.ID]
+
+		switch {
+		case !inBase || !sameTask(b, t):
+			out = append(out, t) // We added or changed it
+		case inTheirs:
+			out = append(out, theirT) // Unchanged here, take theirs
+		}
+		// else: unchanged here and deleted there
+	}
+
+	for _, t := range theirs {
+		if seen[t.ID] {
+			continue
+		}
+		b, inBase := base[t.ID]
+		if !inBase || !sameTask(b, t) {
+			out = append(out, t) // They added it, or changed what we deleted
+		}
+	}
+	return out
+}
+
+// sameTask reports whether a and b hold the same values. Times count as
+// the same if they're the same instant, so a task read back from the file
+// matches the one we wrote even though decoding drops the monotonic clock
+// reading and may pick a different location.
+func sameTask(a, b model.Task) bool {
+	return reflect.DeepEqual(instants(a), instants(b))
+}
+
+// instants returns a copy of t with its times in UTC and without monotonic
+// clock readings.
+func instants(t model.Task) model.Task {
+	t = clone(t)
+	for _, p := range []*time.Time{&t.CreatedAt, t.CompletedAt, t.DeletedAt, t.Due, t.Scheduled} {
+		if p != nil {
+			*p = p.Round(0).UTC()
+		}
+	}
+	return t
+}
+
+// snapshot indexes a copy of tasks by ID for later merges.
+func snapshot(tasks []model.Task) map[string]model.Task {
+	base := make(map[string]model.Task, len(tasks))
+	for _, t := range tasks {
+		base[t.ID] = clone(t)
+	}
+	return base
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+func TestMerge(t *testing.T) {
+	task := func(id, title string) model.Task { return model.Task{ID: id, Title: title} }
+	a, b := task("a", "a"), task("b", "b")
+
+	tests := []struct {
+		name   string
+		base   []model.Task
+		ours   []model.Task
+		theirs []model.Task
+		want   []string // Titles, in order
+	}{
+		{"nothing changed", []model.Task{a, b}, []model.Task{a, b}, []model.Task{a, b}, []string{"a", "b"}},
+		{"we changed it", []model.Task{a}, []model.Task{task("a", "ours")}, []model.Task{a}, []string{"ours"}},
+		{"they changed it", []model.Task{a}, []model.Task{a}, []model.Task{task("a", "theirs")}, []string{"theirs"}},
+		{"both changed it", []model.Task{a}, []model.Task{task("a", "ours")}, []model.Task{task("a", "theirs")}, []string{"ours"}},
+		{"we added one", []model.Task{a}, []model.Task{a, b}, []model.Task{a}, []string{"a", "b"}},
+		{"they added one", []model.Task{a}, []model.Task{a}, []model.Task{a, b}, []string{"a", "b"}},
+		{"both added", nil, []model.Task{a}, []model.Task{b}, []string{"a", "b"}},
+		{"we deleted it", []model.Task{a, b}, []model.Task{b}, []model.Task{a, b}, []string{"b"}},
+		{"they deleted it", []model.Task{a, b}, []model.Task{a, b}, []model.Task{b}, []string{"b"}},
+		{"we deleted what they changed", []model.Task{a, b}, []model.Task{b}, []model.Task{task("a", "theirs"), b}, []string{"b", "theirs"}},
+		{"they deleted what we changed", []model.Task{a, b}, []model.Task{task("a", "ours"), b}, []model.Task{b}, []string{"ours", "b"}},
+		{"both deleted it", []model.Task{a, b}, []model.Task{b}, []model.Task{b}, []string{"b"}},
+		{"our order wins", []model.Task{a, b}, []model.Task{b, a}, []model.Task{a, b}, []string{"b", "a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, t := range merge(snapshot(tt.base), tt.ours, tt.theirs) {
+				got = append(got, t.Title)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("merge = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMergeDecoded checks that tasks read back from the file count as
+// unchanged, though decoding loses the monotonic clock readings that
+// time.Now leaves in ours and in the base.
+func TestMergeDecoded(t *testing.T) {
+	now := time.Now()
+	a := model.Task{ID: "a", Title: "a", CreatedAt: now, CompletedAt: &now, Done: true}
+	b := model.Task{ID: "b", Title: "b", CreatedAt: now}
+	decoded := func(tasks ...model.Task) []model.Task {
+		data, err := json.Marshal(tasks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []model.Task
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	// We archived a while they saved something else
+	base := snapshot([]model.Task{a, b})
+	var got []string
+	for _, t := range merge(base, []model.Task{b}, decoded(a, b)) {
+		got = append(got, t.ID)
+	}
+	if !slices.Equal(got, []string{"b"}) {
+		t.Errorf("merge kept %q after we removed a, want [b]", got)
+	}
+
+	// They edited b, which we didn't touch
+	edited := b
+	edited.Title = "theirs"
+	got = nil
+	for _, t := range merge(base, []model.Task{a, b}, decoded(a, edited)) {
+		got = append(got, t.Title)
+	}
+	if !slices.Equal(got, []string{"a", "theirs"}) {
+		t.Errorf("merge = %q, want [a theirs]", got)
+	}
+}
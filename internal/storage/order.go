@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"atlas.todo/internal/model"
+)
+
+// minGap is how close two ranks may get before Move renumbers the list
+// instead of squeezing another one in between.
+const minGap = 1e-9
+
+// byOrder sorts by manual rank. Tasks with equal ranks, such as ones saved
+// before ranks existed, keep their stored order.
+func byOrder(a, b model.Task) int {
+	return cmp.Compare(a.Order, b.Order)
+}
+
+// Move puts task id right before other in the manual order, or right
+// after it. Ranks are fractional, so usually only the moved task changes;
+// every task is renumbered only when ranks collide (tasks from before
+// ranks existed, say) or there's no room left between two of them.
+func Move(store Backend, id, other string, after bool) error {
+	if _, ok := store.Get(id); !ok {
+		return fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+
+	for renumbered := false; ; renumbered = true {
+		tasks := store.Query(nil)
+		i := slices.IndexFunc(tasks, func(t model.Task) bool { return t.ID == id })
+		moved := tasks[i]
+		tasks = slices.Delete(tasks, i, i+1)
+
+		j := slices.IndexFunc(tasks, func(t model.Task) bool { return t.ID == other })
+		if j < 0 {
+			return fmt.Errorf("%w: %q", ErrNotFound, other)
+		}
+		if after {
+			j++
+		}
+		// The moved task goes between tasks[j-1] and tasks[j]
+		var lo, hi float64
+		switch {
+		case len(tasks) == 0:
+			return nil
+		case j == 0:
+			lo, hi = tasks[0].Order-2, tasks[0].Order
+		case j == len(tasks):
+			lo, hi = tasks[j-1].Order, tasks[j-1].Order+2
+		default:
+			lo, hi = tasks[j-1].Order, tasks[j].Order
+		}
+
+		if hi-lo >= minGap || renumbered {
+			moved.Order = lo + (hi-lo)/2
+			return store.Update(moved)
+		}
+		renumber(store, append(tasks[:j:j], append([]model.Task{moved}, tasks[j:]...)...))
+	}
+}
+
+// renumber ranks tasks 1, 2, 3... in the order given.
+func renumber(store Backend, tasks []model.Task) {
+	for i, t := range tasks {
+		if t.Order != float64(i+1) {
+			t.Order = float64(i + 1)
+			_ = store.Update(t)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"atlas.todo/internal/model"
+)
+
+func TestMove(t *testing.T) {
+	tests := []struct {
+		name      string
+		orders    []float64 // Ranks of a, b, c
+		id, other string
+		after     bool
+		want      []string
+		renumber  bool // Whether every task should end up ranked 1, 2, 3
+	}{
+		{"before the first", []float64{1, 2, 3}, "c", "a", false, []string{"c", "a", "b"}, false},
+		{"after the last", []float64{1, 2, 3}, "a", "c", true, []string{"b", "c", "a"}, false},
+		{"between two", []float64{1, 2, 3}, "c", "a", true, []string{"a", "c", "b"}, false},
+		{"onto its own place", []float64{1, 2, 3}, "b", "a", true, []string{"a", "b", "c"}, false},
+		{"ranks collide", []float64{1, 1, 1}, "c", "b", false, []string{"a", "c", "b"}, true},
+		{"no room between", []float64{1, 1 + 1e-10, 2}, "c", "a", true, []string{"a", "c", "b"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore(
+				model.Task{ID: "a", Title: "a", Order: tt.orders[0]},
+				model.Task{ID: "b", Title: "b", Order: tt.orders[1]},
+				model.Task{ID: "c", Title: "c", Order: tt.orders[2]},
+			)
+			if err := Move(store, tt.id, tt.other, tt.after); err != nil {
+				t.Fatal(err)
+			}
+			if got := titles(store); !slices.Equal(got, tt.want) {
+				t.Fatalf("order %q, want %q", got, tt.want)
+			}
+
+			var ranks []float64
+			for _, task := range store.Query(nil) {
+				ranks = append(ranks, task.Order)
+			}
+			if tt.renumber && !slices.Equal(ranks, []float64{1, 2, 3}) {
+				t.Errorf("ranks %v, want the list renumbered 1, 2, 3", ranks)
+			}
+			if !tt.renumber {
+				// Only the moved task is touched
+				for _, task := range store.Query(nil) {
+					if task.ID != tt.id && task.Order != tt.orders[task.ID[0]-'a'] {
+						t.Errorf("%s was re-ranked to %v", task.ID, task.Order)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMoveUnknown(t *testing.T) {
+	store := NewMemoryStore(model.Task{ID: "a"}, model.Task{ID: "b"})
+	if err := Move(store, "x", "a", false); !errors.Is(err, ErrNotFound) {
+		t.Errorf("moving an unknown task: %v, want ErrNotFound", err)
+	}
+	if err := Move(store, "a", "x", false); !errors.Is(err, ErrNotFound) {
+		t.Errorf("moving next to an unknown task: %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ProjectFile is the name of a per-directory task file, meant to be
+// committed next to the code it's about.
+const ProjectFile = ".atlas-todo.json"
+
+// FindProjectFile walks up from dir looking for a ProjectFile, the way git
+// looks for .git, and returns the first one found.
+func FindProjectFile(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	for {
+		path := filepath.Join(dir, ProjectFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// InitProject creates an empty ProjectFile in dir and returns its path.
+func InitProject(dir string) (string, error) {
+	path := filepath.Join(dir, ProjectFile)
+	if _, err := os.Stat(path); err == nil {
+		return path, fmt.Errorf("%s already exists", path)
+	}
+
+	store, err := NewJSONStore(path)
+	if err != nil {
+		return path, err
+	}
+	return path, store.Save()
+}
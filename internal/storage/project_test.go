@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindProjectFile(t *testing.T) {
+	root := t.TempDir()
+	deep := filepath.Join(root, "src", "pkg")
+	if err := os.MkdirAll(deep, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if path, ok := FindProjectFile(deep); ok {
+		// Only fails if the temp dir itself sits below a project file
+		t.Skipf("found %s above the test's directory", path)
+	}
+
+	path, err := InitProject(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := FindProjectFile(deep); !ok || got != path {
+		t.Errorf("FindProjectFile(%q) = %q, %v; want %q", deep, got, ok, path)
+	}
+	if _, err := InitProject(root); err == nil {
+		t.Error("InitProject replaced an existing project file")
+	}
+
+	// A directory by that name doesn't count
+	if err := os.Mkdir(filepath.Join(deep, ProjectFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := FindProjectFile(deep); got != path {
+		t.Errorf("FindProjectFile stopped at %q, want %q", got, path)
+	}
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"atlas.todo/internal/model"
+)
+
+// DefaultColumns are the board columns when Config.Columns is empty.
+var DefaultColumns = []string{model.StatusTodo, model.StatusDoing, model.StatusBlocked, model.StatusDone}
+
+// BoardColumns returns the board's columns, in order. Done is always
+// there, as the last one.
+func (c Config) BoardColumns() []string {
+	if len(c.Columns) == 0 {
+		return slices.Clone(DefaultColumns)
+	}
+	columns := slices.DeleteFunc(slices.Clone(c.Columns), func(s string) bool { return s == model.StatusDone })
+	return append(columns, model.StatusDone)
+}
+
+// Column returns which of the board columns a task is in. A status that
+// has no column, e.g. after the columns were changed, puts it in the first.
+func Column(columns []string, t model.Task) int {
+	return max(slices.Index(columns, t.CurrentStatus()), 0)
+}
+
+// ParseColumns reads column names for Config.Columns: lower case, no
+// duplicates, and done left implied.
+func ParseColumns(names []string) ([]string, error) {
+	var columns []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == "" || strings.ContainsAny(name, " \t"):
+			return nil, fmt.Errorf("bad column name %q", name)
+		case name == model.StatusDone || slices.Contains(columns, name):
+			continue
+		}
+		columns = append(columns, name)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("the board needs a column besides done")
+	}
+	return append(columns, model.StatusDone), nil
+}
+
+// SetStatus moves a task to another board column. Moving it into or out of
+// done completes or reopens it the way Toggle does, so Done stays in step,
+// and returns the next instance of a recurring task.
+func SetStatus(store Backend, id, status string) (*model.Task, error) {
+	t, ok := store.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+
+	var next *model.Task
+	if t.Done != (status == model.StatusDone) {
+		var err error
+		if next, err = store.Toggle(id); err != nil {
+			return nil, err
+		}
+		t, _ = store.Get(id)
+	}
+	if status == model.StatusDone || t.Status == status {
+		return next, nil
+	}
+	t.Status = status
+	return next, store.Update(t)
+}
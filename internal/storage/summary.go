@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+// Summary holds the task counts a shell prompt shows. JSONStore.Save
+// writes one next to the task file so reading it doesn't mean parsing
+// every task.
+type Summary struct {
+	// The task file the counts were taken from, and the day they hold
+	// for: overdue and due-today counts change at midnight.
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	Day     string    `json:"day"`
+
+	Pending int `json:"pending"`
+	High    int `json:"high"`
+	Medium  int `json:"medium"`
+	Low     int `json:"low"`
+	Overdue int `json:"overdue"`
+	Today   int `json:"today"`
+	Done    int `json:"done"`
+}
+
+// SummaryPath returns where the summary cache for the task file at path
+// lives.
+func SummaryPath(path string) string {
+	return path + ".summary"
+}
+
+// Summarize counts tasks as of now.
+func Summarize(tasks []model.Task, now time.Time) Summary {
+	today := model.StartOfDay(now)
+	tomorrow := today.AddDate(0, 0, 1)
+	sum := Summary{Day: today.Format(model.DateLayout)}
+	for _, t := range tasks {
+		if t.Done {
+			sum.Done++
+			continue
+		}
+		sum.Pending++
+		switch t.Priority {
+		case model.PriorityHigh:
+			sum.High++
+		case model.PriorityLow:
+			sum.Low++
+		default:
+			sum.Medium++
+		}
+		if t.IsOverdue(now) {
+			sum.Overdue++
+		} else if t.Due != nil && t.Due.Before(tomorrow) {
+			sum.Today++
+		}
+	}
+	return sum
+}
+
+// ReadSummary returns the counts for the task file at path. The cache is
+// used while it matches the file and today's date; otherwise the file is
+// loaded in full and the cache rewritten. A missing file has no tasks.
+func ReadSummary(path string, now time.Time) (Summary, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Summarize(nil, now), nil
+	} else if err != nil {
+		return Summary{}, err
+	}
+
+	var cached Summary
+	if data, err := os.ReadFile(SummaryPath(path)); err == nil && json.Unmarshal(data, &cached) == nil {
+		if cached.Size == info.Size() && cached.ModTime.Equal(info.ModTime()) &&
+			cached.Day == model.StartOfDay(now).Format(model.DateLayout) {
+			return cached, nil
+		}
+	}
+
+	store, err := NewJSONStore(path)
+	if err != nil {
+		return Summary{}, err
+	}
+	if err := store.Load(); err != nil {
+		return Summary{}, err
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	// The cache is only a shortcut; failing to refresh it costs speed,
+	// not correctness
+	store.writeSummary(now)
+	return Summarize(live(store.tasks), now), nil
+}
+
+// writeSummary caches the counts for the file as it was last read or
+// written. The caller must hold s.mu.
+func (s *JSONStore) writeSummary(now time.Time) error {
+	sum := Summarize(live(s.tasks), now)
+	sum.Size, sum.ModTime = s.size, s.modTime
+	data, err := json.Marshal(sum)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(SummaryPath(s.filePath), data, 0644)
+}
+
+// live returns the tasks that aren't in the trash.
+func live(tasks []model.Task) []model.Task {
+	out := make([]model.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if t.DeletedAt == nil {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+func TestSummarize(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local)
+	yesterday := model.StartOfDay(now).AddDate(0, 0, -1)
+	tonight := model.StartOfDay(now).Add(20 * time.Hour)
+	tomorrow := model.StartOfDay(now).AddDate(0, 0, 1)
+	tasks := []model.Task{
+		{Priority: model.PriorityHigh, Due: &yesterday},
+		{Priority: model.PriorityHigh, Due: &tonight},
+		{Priority: model.PriorityMedium, Due: &tomorrow},
+		{Priority: model.PriorityLow},
+		{Priority: model.PriorityHigh, Due: &yesterday, Done: true},
+	}
+	want := Summary{Day: "2026-10-14", Pending: 4, High: 2, Medium: 1, Low: 1, Overdue: 1, Today: 1, Done: 1}
+	if got := Summarize(tasks, now); got != want {
+		t.Errorf("Summarize =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestReadSummary(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local)
+	path := filepath.Join(t.TempDir(), "todo.json")
+
+	if sum, err := ReadSummary(path, now); err != nil || sum.Pending != 0 {
+		t.Fatalf("no task file: %+v, %v; want no tasks", sum, err)
+	}
+
+	store := open(t, path)
+	due := model.StartOfDay(now).Add(20 * time.Hour)
+	store.Add(model.Task{Title: "first", Due: &due})
+	store.Add(model.Task{Title: "second"})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(SummaryPath(path)); err != nil {
+		t.Fatalf("Save wrote no summary: %v", err)
+	}
+	if sum, err := ReadSummary(path, now); err != nil || sum.Pending != 2 || sum.Today != 1 {
+		t.Errorf("after Save: %+v, %v; want 2 pending, 1 due today", sum, err)
+	}
+
+	// The task is overdue the next day, though the file hasn't changed
+	sum, err := ReadSummary(path, now.AddDate(0, 0, 1))
+	if err != nil || sum.Overdue != 1 || sum.Today != 0 {
+		t.Errorf("the next day: %+v, %v; want 1 overdue", sum, err)
+	}
+
+	// A file written without the summary, like by an older version, is
+	// counted afresh
+	other := open(t, path)
+	other.Add(model.Task{Title: "third"})
+	if err := other.Save(); err != nil {
+		t.Fatal(err)
+	}
+	write(t, SummaryPath(path), `{"size": 1, "pending": 99}`)
+	if sum, err := ReadSummary(path, now); err != nil || sum.Pending != 3 {
+		t.Errorf("stale cache: %+v, %v; want 3 pending", sum, err)
+	}
+}
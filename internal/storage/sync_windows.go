@@ -0,0 +1,9 @@
+//go:build windows
+
+package storage
+
+// syncDir is a no-op on Windows: directory handles can't be flushed there,
+// and NTFS journals the rename itself.
+func syncDir(dir string) error {
+	return nil
+}
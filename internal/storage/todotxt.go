@@ -0,0 +1,271 @@
+package storage
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"atlas.todo/internal/model"
+	"atlas.todo/internal/todotxt"
+)
+
+// SyncResult counts what a todo.txt sync changed.
+type SyncResult struct {
+	Store     int // Tasks added, edited or deleted in the store
+	File      int // Lines added, edited or removed in the file
+	Conflicts int // Tasks changed on both sides; the store's version won
+}
+
+// ImportTodoTxt adds the tasks on the todo.txt lines to store and returns
+// how many were added and updated. A line tagged with the id of a task
+// that's already there updates it, so importing a file twice doesn't
+// duplicate anything.
+func ImportTodoTxt(store Backend, lines []string, now time.Time) (added, updated int, err error) {
+	var parsed []model.Task
+	for _, line := range lines {
+		t, ok := todotxt.Parse(line, now)
+		if !ok {
+			continue
+		}
+		if _, exists := store.Get(t.ID); exists {
+			updated++
+			err = applyTodoTxt(store, t)
+		} else {
+			added++
+			t = addTodoTxt(store, t)
+		}
+		if err != nil {
+			return added, updated, err
+		}
+		parsed = append(parsed, t)
+	}
+	// Parents can come after their subtasks, so they're set once
+	// everything is in
+	for _, t := range parsed {
+		setTodoTxtParent(store, t)
+	}
+	return added, updated, nil
+}
+
+// SyncTodoTxt reconciles the todo.txt file at file with the store whose
+// task file is at path, matching tasks by their id: tags. It remembers
+// what the file held after the last sync, next to the task file, to tell
+// which side changed a task:
+//
+//   - a task changed or added on one side takes that version on the other
+//   - a task deleted on one side and unchanged on the other is deleted
+//   - a task changed on both sides keeps the store's version
+//   - an edit beats a delete, so no one's work is lost
+//
+// Lines without an id: are new tasks and get one. The store is saved
+// before the file is rewritten, which then lists every task in the store.
+func SyncTodoTxt(store Backend, path, file string, now time.Time) (SyncResult, error) {
+	var res SyncResult
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return res, err
+	}
+	state := readSyncState(path)
+	base := state[abs]
+
+	data, err := os.ReadFile(file)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return res, err
+	}
+	var order []string
+	var fromFile []model.Task
+	theirs := map[string]model.Task{}
+	lines := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		t, ok := todotxt.Parse(line, now)
+		if !ok {
+			continue
+		}
+		if t.ID == "" {
+			t = addTodoTxt(store, t)
+			fromFile = append(fromFile, t)
+			res.Store++
+		} else if _, dup := theirs[t.ID]; dup {
+			continue
+		} else {
+			lines[t.ID] = strings.TrimSpace(line)
+		}
+		order = append(order, t.ID)
+		theirs[t.ID] = t
+	}
+
+	ours := map[string]string{}
+	for _, t := range store.Query(nil) {
+		ours[t.ID] = todotxt.Format(t)
+	}
+
+	for _, id := range order {
+		line, synced := lines[id], base[id]
+		ourLine, inStore := ours[id]
+		switch {
+		case line == "":
+			// Added above
+		case !inStore && synced != "" && line == synced:
+			res.File++ // Deleted in the store
+		case !inStore:
+			// New in the file, or edited there after we deleted it
+			addTodoTxt(store, theirs[id])
+			fromFile = append(fromFile, theirs[id])
+			res.Store++
+		case line == ourLine:
+		case synced != "" && ourLine == synced:
+			if err := applyTodoTxt(store, theirs[id]); err != nil {
+				return res, err
+			}
+			fromFile = append(fromFile, theirs[id])
+			res.Store++
+		default:
+			// Ours changed; theirs too unless it still matches the last sync
+			if synced == "" || line != synced {
+				res.Conflicts++
+			}
+			res.File++
+		}
+	}
+	for id, ourLine := range ours {
+		if _, inFile := theirs[id]; inFile {
+			continue
+		}
+		if synced := base[id]; synced != "" && ourLine == synced {
+			if err := store.Delete(id); err != nil {
+				return res, err
+			}
+			res.Store++
+		} else {
+			res.File++ // New in the store, or edited after the file dropped it
+		}
+	}
+	for _, t := range fromFile {
+		setTodoTxtParent(store, t)
+	}
+
+	if err := store.Save(); err != nil {
+		return res, err
+	}
+
+	// The file keeps its order, with tasks new in the store at the end
+	tasks := store.Query(nil)
+	rank := make(map[string]int, len(order))
+	for i, id := range order {
+		rank[id] = i
+	}
+	position := func(id string) int {
+		if i, ok := rank[id]; ok {
+			return i
+		}
+		return len(order)
+	}
+	var out strings.Builder
+	synced := make(map[string]string, len(tasks))
+	slices.SortStableFunc(tasks, func(a, b model.Task) int {
+		return cmp.Compare(position(a.ID), position(b.ID))
+	})
+	for _, t := range tasks {
+		line := todotxt.Format(t)
+		synced[t.ID] = line
+		out.WriteString(line + "\n")
+	}
+
+	perm := fs.FileMode(0644)
+	if info, err := os.Stat(file); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := writeFileAtomic(file, []byte(out.String()), perm); err != nil {
+		return res, err
+	}
+	state[abs] = synced
+	return res, writeSyncState(path, state)
+}
+
+// addTodoTxt adds a task read from a todo.txt line. Its parent is set
+// afterwards by setTodoTxtParent, once the parent is surely there.
+func addTodoTxt(store Backend, t model.Task) model.Task {
+	parent := t.ParentID
+	t.ParentID = ""
+	t = store.Add(t)
+	t.ParentID = parent
+	return t
+}
+
+// applyTodoTxt updates the task with from's ID to what its todo.txt line
+// says. What a line can't hold, like the description, is left alone.
+func applyTodoTxt(store Backend, from model.Task) error {
+	t, ok := store.Get(from.ID)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrNotFound, from.ID)
+	}
+	if from.CurrentStatus() != t.CurrentStatus() {
+		// Finishing a recurring task this way still spawns the next one
+		if _, err := SetStatus(store, t.ID, from.Status); err != nil {
+			return err
+		}
+		t, _ = store.Get(t.ID)
+	}
+
+	t.Title = from.Title
+	t.Priority = from.Priority
+	t.Project = from.Project
+	t.Category = from.Category
+	t.Contexts = from.Contexts
+	t.Due = from.Due
+	t.Scheduled = from.Scheduled
+	if !t.Done {
+		t.Recur = from.Recur
+	}
+	if !sameDay(t.CreatedAt, from.CreatedAt) {
+		t.CreatedAt = from.CreatedAt
+	}
+	if t.CompletedAt != nil && from.CompletedAt != nil && !sameDay(*t.CompletedAt, *from.CompletedAt) {
+		t.CompletedAt = from.CompletedAt
+	}
+	return store.Update(t)
+}
+
+// setTodoTxtParent nests a task from a todo.txt line under the parent the
+// line names. A parent that doesn't exist or would make a cycle is
+// ignored; the next sync writes the task's actual parent back.
+func setTodoTxtParent(store Backend, from model.Task) {
+	t, ok := store.Get(from.ID)
+	if !ok || t.ParentID == from.ParentID {
+		return
+	}
+	store.SetParent(t.ID, from.ParentID)
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Local().Format(model.DateLayout) == b.Local().Format(model.DateLayout)
+}
+
+// syncStatePath returns where the lines each synced todo.txt file held
+// after its last sync are kept, keyed by the file's absolute path.
+func syncStatePath(path string) string {
+	return path + ".todotxt"
+}
+
+func readSyncState(path string) map[string]map[string]string {
+	state := map[string]map[string]string{}
+	if data, err := os.ReadFile(syncStatePath(path)); err == nil {
+		json.Unmarshal(data, &state)
+	}
+	return state
+}
+
+func writeSyncState(path string, state map[string]map[string]string) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(syncStatePath(path), data, 0644)
+}
@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"atlas.todo/internal/model"
+	"atlas.todo/internal/todotxt"
+)
+
+var syncNow = time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local)
+
+// TestSyncTodoTxt syncs a store holding "Buy milk" (a) and "Call bank"
+// (b) with a new file, changes one or both sides, and syncs again.
+func TestSyncTodoTxt(t *testing.T) {
+	tests := []struct {
+		name  string
+		store func(t *testing.T, store Backend)
+		file  func(line string) string // Rewrites each line; "" drops it
+		want  []string                 // Titles on both sides afterwards
+		res   SyncResult
+	}{
+		{
+			name: "nothing changed",
+			want: []string{"Buy milk", "Call bank"},
+		},
+		{
+			name: "edited in the file",
+			file: edit("id:b", "Call bank", "Call the bank"),
+			want: []string{"Buy milk", "Call the bank"},
+			res:  SyncResult{Store: 1},
+		},
+		{
+			name:  "edited in the store",
+			store: rename("b", "Call the bank"),
+			want:  []string{"Buy milk", "Call the bank"},
+			res:   SyncResult{File: 1},
+		},
+		{
+			name:  "edited on both sides",
+			store: rename("a", "Buy oat milk"),
+			file:  edit("id:a", "Buy milk", "Buy soy milk"),
+			want:  []string{"Buy oat milk", "Call bank"},
+			res:   SyncResult{File: 1, Conflicts: 1},
+		},
+		{
+			name: "deleted in the file",
+			file: edit("id:b", "", ""),
+			want: []string{"Buy milk"},
+			res:  SyncResult{Store: 1},
+		},
+		{
+			name:  "deleted in the store",
+			store: remove("b"),
+			want:  []string{"Buy milk"},
+			res:   SyncResult{File: 1},
+		},
+		{
+			name:  "deleted in the file, edited in the store",
+			store: rename("b", "Call the bank"),
+			file:  edit("id:b", "", ""),
+			want:  []string{"Buy milk", "Call the bank"},
+			res:   SyncResult{File: 1},
+		},
+		{
+			name:  "deleted in the store, edited in the file",
+			store: remove("b"),
+			file:  edit("id:b", "Call bank", "Call the bank"),
+			want:  []string{"Buy milk", "Call the bank"},
+			res:   SyncResult{Store: 1},
+		},
+		{
+			name: "added in the file without an id",
+			file: func(line string) string {
+				if strings.Contains(line, "id:b") {
+					return line + "\nPost the letter"
+				}
+				return line
+			},
+			want: []string{"Buy milk", "Call bank", "Post the letter"},
+			res:  SyncResult{Store: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path, file := filepath.Join(dir, "todo.json"), filepath.Join(dir, "todo.txt")
+			store := NewMemoryStore(
+				model.Task{ID: "a", Title: "Buy milk"},
+				model.Task{ID: "b", Title: "Call bank"},
+			)
+			res, err := SyncTodoTxt(store, path, file, syncNow)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res != (SyncResult{File: 2}) {
+				t.Fatalf("first sync: %+v, want both tasks written to the file", res)
+			}
+
+			if tt.store != nil {
+				tt.store(t, store)
+			}
+			if tt.file != nil {
+				var lines []string
+				for _, line := range readLines(t, file) {
+					if line = tt.file(line); line != "" {
+						lines = append(lines, line)
+					}
+				}
+				write(t, file, strings.Join(lines, "\n")+"\n")
+			}
+
+			res, err = SyncTodoTxt(store, path, file, syncNow)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res != tt.res {
+				t.Errorf("sync: %+v, want %+v", res, tt.res)
+			}
+			if got := titles(store); !slices.Equal(got, tt.want) {
+				t.Errorf("store holds %q, want %q", got, tt.want)
+			}
+			var inFile []string
+			for _, line := range readLines(t, file) {
+				task, _ := todotxt.Parse(line, syncNow)
+				if _, ok := store.Get(task.ID); !ok {
+					t.Errorf("%q names no task in the store", line)
+				}
+				inFile = append(inFile, task.Title)
+			}
+			if !slices.Equal(inFile, tt.want) {
+				t.Errorf("file holds %q, want %q", inFile, tt.want)
+			}
+
+			// Once in step, syncing again changes nothing
+			if res, err := SyncTodoTxt(store, path, file, syncNow); err != nil || res != (SyncResult{}) {
+				t.Errorf("third sync: %+v, %v; want nothing to do", res, err)
+			}
+		})
+	}
+}
+
+func TestImportTodoTxtTwice(t *testing.T) {
+	store := NewMemoryStore()
+	lines := []string{
+		"(A) Call the bank parent:p id:c",
+		"",
+		"Pay rent id:p",
+	}
+	for i, want := range [][2]int{{2, 0}, {0, 2}} {
+		added, updated, err := ImportTodoTxt(store, lines, syncNow)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if added != want[0] || updated != want[1] {
+			t.Errorf("import %d: added %d, updated %d; want %d, %d", i+1, added, updated, want[0], want[1])
+		}
+	}
+	if got := titles(store); len(got) != 2 {
+		t.Errorf("store holds %q after importing twice, want 2 tasks", got)
+	}
+	if c, _ := store.Get("c"); c.ParentID != "p" {
+		t.Errorf("subtask's parent = %q, want the task listed after it", c.ParentID)
+	}
+}
+
+// edit returns a line rewrite that replaces old with new on the line
+// containing match, or drops that line if new and old are both empty.
+func edit(match, old, new string) func(string) string {
+	return func(line string) string {
+		if !strings.Contains(line, match) {
+			return line
+		}
+		if old == "" && new == "" {
+			return ""
+		}
+		return strings.Replace(line, old, new, 1)
+	}
+}
+
+func rename(id, title string) func(*testing.T, Backend) {
+	return func(t *testing.T, store Backend) {
+		task, _ := store.Get(id)
+		task.Title = title
+		if err := store.Update(task); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func remove(id string) func(*testing.T, Backend) {
+	return func(t *testing.T, store Backend) {
+		if err := store.Delete(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readLines(t *testing.T, file string) []string {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
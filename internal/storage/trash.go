@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+// DefaultTrashDays is how long deleted tasks stay in the trash unless
+// Config.TrashDays says otherwise.
+const DefaultTrashDays = 30
+
+// TrashRetention returns how many days deleted tasks are kept in the trash,
+// or 0 if they stay until purged by hand.
+func (c Config) TrashRetention() int {
+	switch {
+	case c.TrashDays == 0:
+		return DefaultTrashDays
+	case c.TrashDays < 0:
+		return 0
+	}
+	return c.TrashDays
+}
+
+// PurgeExpired permanently removes the tasks that have been in the trash
+// longer than the retention, and saves if there were any.
+func PurgeExpired(store Backend, now time.Time) ([]model.Task, error) {
+	days := store.Config().TrashRetention()
+	if days == 0 {
+		return nil, nil
+	}
+	cutoff := now.AddDate(0, 0, -days)
+
+	var ids []string
+	for _, t := range store.Trash() {
+		if t.DeletedAt.Before(cutoff) {
+			ids = append(ids, t.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	purged := store.Remove("purge", ids...)
+	return purged, store.Save()
+}
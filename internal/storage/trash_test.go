@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+func TestPurgeExpired(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.Local)
+	daysAgo := func(n int) *time.Time {
+		d := now.AddDate(0, 0, -n)
+		return &d
+	}
+	tests := []struct {
+		name   string
+		days   int // Config.TrashDays
+		purged []string
+	}{
+		{"default retention", 0, []string{"old"}},
+		{"a week", 7, []string{"week", "old"}},
+		{"forever", -1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore(
+				model.Task{ID: "live", Title: "live"},
+				model.Task{ID: "new", Title: "new", DeletedAt: daysAgo(1)},
+				model.Task{ID: "week", Title: "week", DeletedAt: daysAgo(8)},
+				model.Task{ID: "old", Title: "old", DeletedAt: daysAgo(31)},
+			)
+			cfg := store.Config()
+			cfg.TrashDays = tt.days
+			store.SetConfig(cfg)
+
+			purged, err := PurgeExpired(store, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ids(purged); !slices.Equal(got, tt.purged) {
+				t.Errorf("purged %q, want %q", got, tt.purged)
+			}
+			if _, ok := store.Get("live"); !ok {
+				t.Error("purged a task that isn't in the trash")
+			}
+		})
+	}
+}
+
+func TestDeleteAndRestore(t *testing.T) {
+	store := NewMemoryStore(
+		model.Task{ID: "a", Title: "parent"},
+		model.Task{ID: "b", Title: "child", ParentID: "a"},
+	)
+	if err := store.Delete("a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.Get("a"); ok {
+		t.Error("a deleted task is still listed")
+	}
+	if b, _ := store.Get("b"); b.ParentID != "" {
+		t.Errorf("the child stayed under the deleted task %q", b.ParentID)
+	}
+	if trash := store.Trash(); len(trash) != 1 || trash[0].ID != "a" {
+		t.Errorf("trash holds %q, want [a]", ids(trash))
+	}
+
+	if err := store.Undelete("a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.Get("a"); !ok || len(store.Trash()) != 0 {
+		t.Error("the restored task is still in the trash")
+	}
+	if err := store.Undelete("a"); err == nil {
+		t.Error("restored a task that isn't in the trash")
+	}
+}
+
+func TestRestoreTakesBackSubtasks(t *testing.T) {
+	store := NewMemoryStore(
+		model.Task{ID: "g", Title: "grandparent"},
+		model.Task{ID: "a", Title: "parent", ParentID: "g"},
+		model.Task{ID: "b", Title: "child", ParentID: "a"},
+		model.Task{ID: "c", Title: "moved child", ParentID: "a"},
+	)
+	if err := store.Delete("a"); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := store.Get("b"); b.ParentID != "g" {
+		t.Fatalf("b is under %q after deleting a, want g", b.ParentID)
+	}
+	if err := store.SetParent("c", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Undelete("a"); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"a": "g", "b": "a", "c": ""}
+	for id, parent := range want {
+		if task, _ := store.Get(id); task.ParentID != parent {
+			t.Errorf("%s is under %q after the restore, want %q", id, task.ParentID, parent)
+		}
+	}
+	if a, _ := store.Get("a"); a.Orphaned != nil {
+		t.Errorf("the restored task still lists %q as orphaned", a.Orphaned)
+	}
+}
@@ -0,0 +1,224 @@
+// Package todotxt reads and writes tasks as todo.txt lines
+// (https://github.com/todotxt/todo.txt), for 'atlas.todo import', 'export'
+// and 'sync'.
+//
+// A line looks like
+//
+//	x 2026-10-18 2026-10-01 Call the bank +house @phone due:2026-10-20 id:01J...
+//
+// Priority (A) is high and (C) and below are low; medium, the default, is
+// written without one. The last +project becomes the project; unlike
+// quick-add, which drops them, any others stay in the title. The first
+// @context becomes the category and further ones the contexts. These
+// key:value extensions carry the rest: due, t (scheduled), rec, status,
+// parent, pri (the priority of a finished task) and id, which sync
+// matches tasks by. Anything else, other extensions included, stays in
+// the title.
+//
+// A title word that would read as one of these, like "status:page" in
+// "Check status:page", is written with a backslash in front
+// (\status:page) so it reads back as part of the title.
+package todotxt
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+// Parse reads one todo.txt line. Blank lines report false. A line without
+// a creation date is taken to be created now.
+func Parse(line string, now time.Time) (model.Task, bool) {
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return model.Task{}, false
+	}
+
+	t := model.Task{Priority: model.PriorityMedium, CreatedAt: now}
+	if words[0] == "x" {
+		t.Done = true
+		t.Status = model.StatusDone
+		words = words[1:]
+		if d, ok := date(words); ok {
+			t.CompletedAt = &d
+			words = words[1:]
+		}
+	} else if p, ok := priority(words[0]); ok {
+		t.Priority = p
+		words = words[1:]
+	}
+	if d, ok := date(words); ok {
+		t.CreatedAt = d
+		words = words[1:]
+	}
+
+	project := -1
+	for i, w := range words {
+		if isProject(w) {
+			project = i
+		}
+	}
+
+	var title []string
+	for i, w := range words {
+		key, value, _ := strings.Cut(w, ":")
+		switch {
+		case strings.HasPrefix(w, `\`) && tagLike(w[1:]):
+			title = append(title, w[1:])
+		case i == project:
+			t.Project = w[1:]
+		case strings.HasPrefix(w, "@") && len(w) > 1:
+			if t.Category == "" {
+				t.Category = w[1:]
+			} else if w[1:] != t.Category && !slices.Contains(t.Contexts, w[1:]) {
+				t.Contexts = append(t.Contexts, w[1:])
+			}
+		case value == "":
+			title = append(title, w)
+		case key == "due" || key == "t":
+			d, ok := date([]string{value})
+			if !ok {
+				title = append(title, w)
+			} else if key == "due" {
+				t.Due = &d
+			} else {
+				t.Scheduled = &d
+			}
+		case key == "rec":
+			// todo.txt counts "1w" from the due date; atlas.todo's closest
+			// rule counts from completion
+			rule := strings.ToLower(value)
+			if !model.ValidRecurrence(rule) {
+				rule = "+" + strings.TrimPrefix(rule, "+")
+			}
+			if model.ValidRecurrence(rule) {
+				t.Recur = rule
+			} else {
+				title = append(title, w)
+			}
+		case key == "status":
+			if !t.Done {
+				t.Status = strings.ToLower(value)
+			}
+		case key == "pri":
+			if p, ok := priority("(" + value + ")"); ok {
+				t.Priority = p
+			} else {
+				title = append(title, w)
+			}
+		case key == "parent":
+			t.ParentID = value
+		case key == "id":
+			t.ID = value
+		default:
+			title = append(title, w)
+		}
+	}
+	t.Title = strings.Join(title, " ")
+	return t, true
+}
+
+// Format writes t as a todo.txt line that Parse reads back to the same
+// task, description and manual order aside, and with the title's spacing
+// collapsed.
+func Format(t model.Task) string {
+	var parts []string
+	letter := map[model.Priority]string{model.PriorityHigh: "A", model.PriorityLow: "C"}[t.Priority]
+	if t.Done {
+		completed := t.CreatedAt
+		if t.CompletedAt != nil {
+			completed = *t.CompletedAt
+		}
+		parts = append(parts, "x", completed.Local().Format(model.DateLayout))
+	} else if letter != "" {
+		parts = append(parts, "("+letter+")")
+	}
+	parts = append(parts, t.CreatedAt.Local().Format(model.DateLayout))
+	for _, w := range strings.Fields(t.Title) {
+		// Extra projects can stay as they are: the task's own comes last
+		if tagLike(w) && !(isProject(w) && t.Project != "") {
+			w = `\` + w
+		}
+		parts = append(parts, w)
+	}
+	if t.Project != "" {
+		parts = append(parts, "+"+t.Project)
+	}
+	if t.Category != "" {
+		parts = append(parts, "@"+t.Category)
+	}
+	for _, c := range t.Contexts {
+		parts = append(parts, "@"+c)
+	}
+	if t.Due != nil {
+		parts = append(parts, "due:"+t.Due.Local().Format(model.DateLayout))
+	}
+	if t.Scheduled != nil {
+		parts = append(parts, "t:"+t.Scheduled.Local().Format(model.DateLayout))
+	}
+	if t.Recur != "" {
+		parts = append(parts, "rec:"+t.Recur)
+	}
+	if s := t.CurrentStatus(); s != model.StatusTodo && s != model.StatusDone {
+		parts = append(parts, "status:"+s)
+	}
+	if t.Done && letter != "" {
+		parts = append(parts, "pri:"+letter)
+	}
+	if t.ParentID != "" {
+		parts = append(parts, "parent:"+t.ParentID)
+	}
+	if t.ID != "" {
+		parts = append(parts, "id:"+t.ID)
+	}
+	return strings.Join(parts, " ")
+}
+
+// priority reads "(A)" style priorities: A is high, B medium and the
+// rest low.
+func priority(w string) (model.Priority, bool) {
+	if len(w) != 3 || w[0] != '(' || w[2] != ')' || w[1] < 'A' || w[1] > 'Z' {
+		return model.PriorityMedium, false
+	}
+	switch w[1] {
+	case 'A':
+		return model.PriorityHigh, true
+	case 'B':
+		return model.PriorityMedium, true
+	}
+	return model.PriorityLow, true
+}
+
+// date reads a YYYY-MM-DD date from the first of words, as local midnight.
+func date(words []string) (time.Time, bool) {
+	if len(words) == 0 {
+		return time.Time{}, false
+	}
+	d, err := time.ParseInLocation(model.DateLayout, words[0], time.Local)
+	return d, err == nil
+}
+
+// isProject reports whether w is a +project. The name must start with a
+// letter, the same rule as the quick-add syntax, so "+1" stays in the
+// title.
+func isProject(w string) bool {
+	return len(w) > 1 && w[0] == '+' && (w[1] >= 'a' && w[1] <= 'z' || w[1] >= 'A' && w[1] <= 'Z')
+}
+
+// tagLike reports whether Parse would take w out of the title, or w is
+// such a word already escaped with a backslash.
+func tagLike(w string) bool {
+	key, value, _ := strings.Cut(w, ":")
+	switch {
+	case strings.HasPrefix(w, `\`):
+		return tagLike(w[1:])
+	case isProject(w), strings.HasPrefix(w, "@") && len(w) > 1:
+		return true
+	}
+	return value != "" && slices.Contains(tagKeys, key)
+}
+
+// tagKeys are the key:value extensions Parse reads.
+var tagKeys = []string{"due", "t", "rec", "status", "pri", "parent", "id"}
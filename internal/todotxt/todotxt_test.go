@@ -0,0 +1,107 @@
+package todotxt
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+var now = time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local)
+
+func day(s string) *time.Time {
+	d, _ := time.ParseInLocation(model.DateLayout, s, time.Local)
+	return &d
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		line string
+		want model.Task
+	}{
+		{
+			"Buy milk",
+			model.Task{Title: "Buy milk", Priority: model.PriorityMedium, CreatedAt: now},
+		},
+		{
+			"(A) 2026-10-01 Call the bank +house @phone @town due:2026-10-20 id:01J",
+			model.Task{
+				ID: "01J", Title: "Call the bank", Priority: model.PriorityHigh, CreatedAt: *day("2026-10-01"),
+				Project: "house", Category: "phone", Contexts: []string{"town"}, Due: day("2026-10-20"),
+			},
+		},
+		{
+			"(D) Sweep t:2026-10-19 rec:1w status:doing parent:01P",
+			model.Task{
+				Title: "Sweep", Priority: model.PriorityLow, CreatedAt: now,
+				Scheduled: day("2026-10-19"), Recur: "+1w", Status: model.StatusDoing, ParentID: "01P",
+			},
+		},
+		{
+			"x 2026-10-18 2026-10-02 Write notes pri:A status:doing",
+			model.Task{
+				Title: "Write notes", Priority: model.PriorityHigh, Done: true, Status: model.StatusDone,
+				CompletedAt: day("2026-10-18"), CreatedAt: *day("2026-10-02"),
+			},
+		},
+		{
+			// Only the last +project is the task's; others stay in the title
+			"Plan +trip with +family",
+			model.Task{Title: "Plan +trip with", Project: "family", Priority: model.PriorityMedium, CreatedAt: now},
+		},
+		{
+			`Check \status:page and url:x.org due:soon +1`,
+			model.Task{Title: "Check status:page and url:x.org due:soon +1", Priority: model.PriorityMedium, CreatedAt: now},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := Parse(tt.line, now)
+			if !ok {
+				t.Fatalf("Parse(%q) reported a blank line", tt.line)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) =\n%+v\nwant\n%+v", tt.line, got, tt.want)
+			}
+		})
+	}
+
+	if _, ok := Parse("   ", now); ok {
+		t.Error("Parse of a blank line reported a task")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tasks := []model.Task{
+		{ID: "a", Title: "Buy milk", Priority: model.PriorityMedium, CreatedAt: *day("2026-10-01")},
+		{
+			ID: "b", Title: "Call the bank", Priority: model.PriorityHigh, CreatedAt: *day("2026-10-01"),
+			Project: "house", Category: "phone", Contexts: []string{"town", "car"},
+			Due: day("2026-10-20"), Scheduled: day("2026-10-19"), ParentID: "a",
+		},
+		{
+			ID: "c", Title: "Water plants", Priority: model.PriorityLow, CreatedAt: *day("2026-10-01"),
+			Recur: "weekly", Status: model.StatusBlocked,
+		},
+		{
+			ID: "d", Title: "Write notes", Priority: model.PriorityLow, Done: true, Status: model.StatusDone,
+			CompletedAt: day("2026-10-18"), CreatedAt: *day("2026-10-02"),
+		},
+		{ID: "e", Title: "Check status:page for @ops and +infra", Priority: model.PriorityMedium, CreatedAt: *day("2026-10-03")},
+		{ID: "f", Title: "Plan +trip", Project: "family", Priority: model.PriorityMedium, CreatedAt: *day("2026-10-03")},
+		{ID: "g", Title: `A \due:tomorrow id:x`, Priority: model.PriorityMedium, CreatedAt: *day("2026-10-03")},
+	}
+	for _, want := range tasks {
+		t.Run(want.Title, func(t *testing.T) {
+			line := Format(want)
+			got, ok := Parse(line, now)
+			if !ok {
+				t.Fatalf("Parse(%q) reported a blank line", line)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%q read back as\n%+v\nwant\n%+v", line, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"sort"
+
+	"atlas.todo/internal/model"
+	"atlas.todo/internal/storage"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openArchive loads the archive that belongs to the open task file, newest
+// completion first, and switches to the archive view.
+func (m Model) openArchive() (tea.Model, tea.Cmd) {
+	archive, err := m.store.OpenArchive()
+	if err != nil {
+		m.statusMsg = "⚠ " + err.Error()
+		return m, clearStatus()
+	}
+
+	tasks := archive.Query(nil)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return storage.CompletedAt(tasks[i]).After(storage.CompletedAt(tasks[j]))
+	})
+	m.archive = archive
+	m.archived.set(tasks)
+	m.state = browsingArchive
+	return m, nil
+}
+
+// updateArchive handles keys in the archive view, which is read-only
+// except for restoring tasks.
+func (m Model) updateArchive(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "r", "enter":
+		t, ok := m.archived.selected()
+		if !ok {
+			return m, nil
+		}
+		if _, err := storage.Unarchive(m.store, m.archive, t.ID); err != nil {
+			m.statusMsg = "⚠ " + err.Error()
+			return m, clearStatus()
+		}
+		m.statusMsg = "↺ Restored " + t.Title
+		next, _ := m.openArchive()
+		return next, clearStatus()
+	case "esc", "q", "A":
+		m.state = browsing
+		m.archive = nil
+	default:
+		m.archived.move(msg.String())
+	}
+	return m, nil
+}
+
+func (m Model) archiveView(lines int) string {
+	content := m.archived.view("Archive", "Nothing archived yet. Run 'atlas.todo archive' to move finished tasks here.", lines,
+		func(t model.Task) string {
+			return "done " + storage.CompletedAt(t).Local().Format(model.DateLayout)
+		})
+	if m.statusMsg != "" {
+		content += "\n" + statusStyle.Render(m.statusMsg)
+	}
+	return content + "\n" + helpStyle.Render("(r/enter: restore • esc: back)")
+}
@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"atlas.todo/internal/model"
+	"atlas.todo/internal/storage"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// columns splits the shown tasks into board columns. Each column holds
+// indexes into tasks, so the cursor and every list action keep working.
+func (m Model) columns(tasks []model.Task) ([]string, [][]int) {
+	names := m.store.Config().BoardColumns()
+	cols := make([][]int, len(names))
+	for i, t := range tasks {
+		c := storage.Column(names, t)
+		cols[c] = append(cols[c], i)
+	}
+	return names, cols
+}
+
+// boardKey handles the keys that mean something else on the board:
+// left/right jump between columns, up/down stay within one, and H/L move
+// the card under the cursor to the column before or after. It reports
+// whether it used the key.
+func (m Model) boardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	tasks := m.filteredTasks()
+	if m.cursor >= len(tasks) {
+		return m, nil, false
+	}
+	names, cols := m.columns(tasks)
+	col := storage.Column(names, tasks[m.cursor])
+	row := 0
+	for r, i := range cols[col] {
+		if i == m.cursor {
+			row = r
+		}
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if row > 0 {
+			m.cursor = cols[col][row-1]
+		}
+	case "down", "j":
+		if row < len(cols[col])-1 {
+			m.cursor = cols[col][row+1]
+		}
+	case "left", "right":
+		step := 1
+		if msg.String() == "left" {
+			step = -1
+		}
+		for c := col + step; c >= 0 && c < len(cols); c += step {
+			if len(cols[c]) > 0 {
+				m.cursor = cols[c][min(row, len(cols[c])-1)]
+				break
+			}
+		}
+	case "H", "L":
+		to := col + 1
+		if msg.String() == "H" {
+			to = col - 1
+		}
+		if to < 0 || to >= len(names) {
+			return m, nil, true
+		}
+		id := tasks[m.cursor].ID
+		next, err := storage.SetStatus(m.store, id, names[to])
+		if err != nil {
+			m.statusMsg = "⚠ " + err.Error()
+			return m, clearStatus(), true
+		}
+		_ = m.store.Save()
+		m.followTask(id)
+		if next != nil {
+			m.statusMsg = "↻ Next due " + formatDue(*next.Due, time.Now())
+			return m, clearStatus(), true
+		}
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
+
+// followTask puts the cursor back on a task after it moved in the list,
+// or keeps it in range if the task isn't shown any more.
+func (m *Model) followTask(id string) {
+	for i, t := range m.filteredTasks() {
+		if t.ID == id {
+			m.cursor = i
+			return
+		}
+	}
+	m.clampCursor()
+}
+
+// boardView renders the tasks as cards in one column per status, in lines
+// rows. Columns scroll on their own; the cursor's column keeps it in view.
+func (m Model) boardView(tasks []model.Task, lines int) string {
+	names, cols := m.columns(tasks)
+	_, roots := layout(tasks)
+	width := max((m.width-8)/len(names)-2, 14)
+
+	var rendered []string
+	for c, name := range names {
+		head := boardHeaderStyle.Render(fmt.Sprintf("%s (%d)", strings.ToUpper(name), len(cols[c])))
+		var rows []string
+		lastGroup := ""
+		for _, i := range cols[c] {
+			t := tasks[i]
+			if m.grouping != GroupNone {
+				if key := m.groupKey(roots[i]); key != lastGroup {
+					rows = append(rows, groupHeaderStyle.Render(truncate(key, width)))
+					lastGroup = key
+				}
+			}
+			rows = append(rows, m.card(t, i == m.cursor, width))
+		}
+		if len(rows) == 0 {
+			empty := "(empty)"
+			if name == model.StatusDone && !m.showDone {
+				empty = "(hidden, c shows)"
+			}
+			rows = append(rows, helpStyle.Render(empty))
+		}
+
+		// Window the column around the cursor when it's in this one
+		budget := max(lines-2, 1)
+		start := 0
+		for r, row := range rows {
+			if strings.HasPrefix(row, cursorStyle.Render("❯")) {
+				start = max(0, min(r-budget/2, len(rows)-budget))
+			}
+		}
+		end := min(len(rows), start+budget)
+		if end < len(rows) {
+			end = max(end-1, start)
+			rows = append(rows[start:end], helpStyle.Render(fmt.Sprintf("+%d more", len(rows)-end)))
+		} else {
+			rows = rows[start:end]
+		}
+
+		style := boardColumnStyle.Width(width).Height(lines)
+		if c == len(names)-1 {
+			style = style.BorderRight(false)
+		}
+		rendered = append(rendered, style.Render(head+"\n\n"+strings.Join(rows, "\n")))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...) + "\n"
+}
+
+// card is one task on the board: a single line with its title cut to fit.
+func (m Model) card(t model.Task, current bool, width int) string {
+	cursor := " "
+	if current {
+		cursor = cursorStyle.Render("❯")
+	}
+	mark := " "
+	if m.selected[t.ID] {
+		mark = markStyle.Render("●")
+	}
+	prio := ""
+	if t.Priority == model.PriorityHigh {
+		prio = overdueStyle.Render("!")
+	}
+	title := truncate(t.Title, width-4-lipgloss.Width(prio))
+	switch {
+	case t.Done:
+		title = doneStyle.Render(title)
+	case current:
+		title = selectedItemStyle.UnsetPaddingLeft().Render(title)
+	}
+	return cursor + mark + prio + title
+}
+
+// truncate cuts s to width cells, ending it with … if anything was cut.
+func truncate(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if lipgloss.Width(b.String()+string(r)) > width-1 {
+			break
+		}
+		b.WriteRune(r)
+	}
+	return b.String() + "…"
+}
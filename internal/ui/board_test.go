@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"testing"
+
+	"atlas.todo/internal/model"
+)
+
+func TestBoardKeys(t *testing.T) {
+	m, store := newTestModel("a", "b", "c")
+	m.board = true
+
+	// L moves a card right, following it
+	m = press(m, "L")
+	if task, _ := store.Get("a"); task.CurrentStatus() != model.StatusDoing {
+		t.Fatalf("a is %q after L, want doing", task.CurrentStatus())
+	}
+	if cur := m.filteredTasks()[m.cursor].ID; cur != "a" {
+		t.Errorf("cursor on %s after L, want a", cur)
+	}
+
+	// Down stays within the doing column, left jumps to todo
+	m = press(m, "down")
+	if cur := m.filteredTasks()[m.cursor].ID; cur != "a" {
+		t.Errorf("cursor on %s after down in a one-card column, want a", cur)
+	}
+	m = press(m, "left", "down")
+	if cur := m.filteredTasks()[m.cursor].ID; cur != "c" {
+		t.Errorf("cursor on %s, want c", cur)
+	}
+
+	// Moving into done completes the task, and H from the first column
+	// does nothing
+	m = press(m, "L", "L", "L")
+	if task, _ := store.Get("c"); !task.Done {
+		t.Errorf("c is %q, want done", task.CurrentStatus())
+	}
+	m = press(m, "left", "left", "H")
+	if task, _ := store.Get(m.filteredTasks()[m.cursor].ID); task.CurrentStatus() != model.StatusTodo {
+		t.Errorf("H in the first column moved %s to %q", task.ID, task.CurrentStatus())
+	}
+}
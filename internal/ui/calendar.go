@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"atlas.todo/internal/agenda"
+	"atlas.todo/internal/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// agendaDays is how many days the agenda shows.
+const agendaDays = 7
+
+// openCalendar switches to the calendar on today's month.
+func (m Model) openCalendar() (tea.Model, tea.Cmd) {
+	m.calDay = model.StartOfDay(time.Now())
+	m.state = viewingCalendar
+	return m, nil
+}
+
+// updateCalendar handles keys in the calendar: the arrows (or h/j/k/l)
+// move the selected day, [ and ] the month, t jumps back to today and tab
+// switches between the month grid and the agenda.
+func (m Model) updateCalendar(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "left", "h":
+		m.calDay = m.calDay.AddDate(0, 0, -1)
+	case "right", "l":
+		m.calDay = m.calDay.AddDate(0, 0, 1)
+	case "up", "k":
+		m.calDay = m.calDay.AddDate(0, 0, -7)
+	case "down", "j":
+		m.calDay = m.calDay.AddDate(0, 0, 7)
+	case "[":
+		m.calDay = shiftMonth(m.calDay, -1)
+	case "]":
+		m.calDay = shiftMonth(m.calDay, 1)
+	case "t":
+		m.calDay = model.StartOfDay(time.Now())
+	case "tab", "a":
+		m.calAgenda = !m.calAgenda
+	case "esc", "q", "C":
+		m.state = browsing
+	}
+	return m, nil
+}
+
+// shiftMonth moves day n months, to the same day of the month or, if the
+// month is shorter, its last day. AddDate would run on into the month
+// after: from 31 January it gives 3 March.
+func shiftMonth(day time.Time, n int) time.Time {
+	first := time.Date(day.Year(), day.Month()+time.Month(n), 1, 0, 0, 0, 0, day.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day.Day(), last)-1)
+}
+
+func (m Model) calendarView(lines int) string {
+	// Every match, including subtasks hidden under collapsed parents
+	tasks := m.matchingTasks()
+	now := time.Now()
+	if m.calAgenda {
+		return m.agendaView(tasks, now, lines)
+	}
+
+	first := time.Date(m.calDay.Year(), m.calDay.Month(), 1, 0, 0, 0, 0, time.Local)
+	days := agenda.Days(tasks, first, first.AddDate(0, 1, -1).Day())
+
+	content := titleStyle.Render(first.Format("January 2006")) + "\n\n"
+	var row []string
+	for _, name := range []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"} {
+		row = append(row, calCellStyle.Render(helpStyle.Render(name)))
+	}
+	content += strings.Join(row, "") + "\n"
+
+	// Pad the first week out to Monday
+	row = nil
+	for i := 0; i < (int(first.Weekday())+6)%7; i++ {
+		row = append(row, calCellStyle.Render(""))
+	}
+	for _, day := range days {
+		text := fmt.Sprintf("%2d", day.Date.Day())
+		if n := len(day.Entries); n > 0 {
+			text += fmt.Sprintf("·%d", n)
+		}
+		style := calCellStyle
+		switch {
+		case day.Date.Equal(m.calDay):
+			style = style.Inherit(calSelectedStyle)
+		case overdueOn(day, now):
+			style = style.Inherit(overdueStyle)
+		case day.Date.Equal(model.StartOfDay(now)):
+			style = style.Inherit(calTodayStyle)
+		case len(day.Entries) == 0:
+			style = style.Inherit(helpStyle)
+		}
+		row = append(row, style.Render(text))
+		if len(row) == 7 {
+			content += strings.Join(row, "") + "\n"
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		content += strings.Join(row, "") + "\n"
+	}
+
+	selected := days[m.calDay.Day()-1]
+	content += "\n" + groupHeaderStyle.Render(m.calDay.Format("Monday, 02 Jan")) + "\n"
+	content += strings.Join(entryLines(selected.Entries, now, max(lines-12, 1)), "\n") + "\n"
+	return content + "\n" + helpStyle.Render("(←/→/↑/↓: day • [/]: month • t: today • tab: agenda • esc: back)")
+}
+
+// agendaView lists the week from the selected day, after what's overdue.
+func (m Model) agendaView(tasks []model.Task, now time.Time, lines int) string {
+	content := titleStyle.Render("Agenda · "+m.calDay.Format("02 Jan")+" to "+m.calDay.AddDate(0, 0, agendaDays-1).Format("02 Jan")) + "\n\n"
+
+	var rows []string
+	if overdue := agenda.Overdue(tasks, now); len(overdue) > 0 {
+		rows = append(rows, overdueStyle.Render(fmt.Sprintf("Overdue (%d)", len(overdue))))
+		for _, t := range overdue {
+			rows = append(rows, "  "+checkboxStyle.Render("☐")+" "+t.Title+" "+overdueStyle.Render("due "+formatDue(*t.Due, now)))
+		}
+		rows = append(rows, "")
+	}
+	for _, day := range agenda.Days(tasks, m.calDay, agendaDays) {
+		heading := day.Date.Format("Mon 02 Jan")
+		if day.Date.Equal(model.StartOfDay(now)) {
+			heading = "Today · " + heading
+		}
+		rows = append(rows, groupHeaderStyle.Render(heading))
+		rows = append(rows, entryLines(day.Entries, now, len(day.Entries)+1)...)
+	}
+	if len(rows) > lines {
+		rows = append(rows[:max(lines-1, 1)], helpStyle.Render("…"))
+	}
+	return content + strings.Join(rows, "\n") + "\n\n" + helpStyle.Render("(←/→: day • ↑/↓: week • t: today • tab: month • esc: back)")
+}
+
+// entryLines renders a day's tasks, at most limit lines of them.
+func entryLines(entries []agenda.Entry, now time.Time, limit int) []string {
+	if len(entries) == 0 {
+		return []string{helpStyle.Render("  Nothing planned.")}
+	}
+	var lines []string
+	for i, e := range entries {
+		if i == limit-1 && len(entries) > limit {
+			lines = append(lines, helpStyle.Render(fmt.Sprintf("  +%d more", len(entries)-i)))
+			break
+		}
+		t := e.Task
+		line := checkboxStyle.Render("☐") + " " + t.Title
+		if t.Done {
+			line = checkedStyle.Render("☑") + " " + doneStyle.Render(t.Title)
+		}
+		if t.Category != "" {
+			line += " " + categoryStyle.Render("(@"+t.Category+")")
+		}
+		switch {
+		case e.Due && t.IsOverdue(now):
+			line += " " + overdueStyle.Render("due")
+		case e.Due:
+			line += " " + dueStyle.Render("due")
+		case t.Scheduled != nil:
+			line += " " + scheduledStyle.Render("scheduled")
+		}
+		lines = append(lines, "  "+line)
+	}
+	return lines
+}
+
+// overdueOn reports whether an open task was due on a day that's passed.
+func overdueOn(day agenda.Day, now time.Time) bool {
+	for _, e := range day.Entries {
+		if t := e.Task; t.IsOverdue(now) && model.StartOfDay(t.Due.Local()).Equal(day.Date) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+func TestShiftMonth(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.ParseInLocation(model.DateLayout, s, time.Local)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	tests := []struct {
+		day  string
+		n    int
+		want string
+	}{
+		{"2026-10-18", 1, "2026-11-18"},
+		{"2026-10-18", -1, "2026-09-18"},
+		{"2026-01-31", 1, "2026-02-28"},
+		{"2028-01-31", 1, "2028-02-29"},
+		{"2026-03-31", -1, "2026-02-28"},
+		{"2026-05-31", 1, "2026-06-30"},
+		{"2026-12-31", 1, "2027-01-31"},
+		{"2026-01-30", -1, "2025-12-30"},
+	}
+	for _, tt := range tests {
+		if got := shiftMonth(date(tt.day), tt.n).Format(model.DateLayout); got != tt.want {
+			t.Errorf("shiftMonth(%s, %d) = %s, want %s", tt.day, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestCalendarShowsCollapsedSubtasks(t *testing.T) {
+	m, store := newTestModel("parent")
+	parent, _ := store.Get("parent")
+	parent.Collapsed = true
+	if err := store.Update(parent); err != nil {
+		t.Fatal(err)
+	}
+	today := model.StartOfDay(time.Now())
+	store.Add(model.Task{ID: "child", Title: "hidden subtask", ParentID: "parent", Scheduled: &today})
+
+	m = press(m, "C")
+	if view := m.calendarView(40); !strings.Contains(view, "hidden subtask") {
+		t.Errorf("the calendar leaves out a subtask of a collapsed parent:\n%s", view)
+	}
+	m = press(m, "tab")
+	if view := m.calendarView(40); !strings.Contains(view, "hidden subtask") {
+		t.Errorf("the agenda leaves out a subtask of a collapsed parent:\n%s", view)
+	}
+}
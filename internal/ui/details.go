@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"atlas.todo/internal/model"
+	"atlas.todo/internal/storage"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Field is one labelled detail of a task.
+type Field struct {
+	Name, Value string
+}
+
+// Details lists a task's fields that are set, in the order the detail pane
+// and 'atlas.todo show' print them.
+func Details(store storage.Backend, t model.Task, now time.Time) []Field {
+	var fields []Field
+	add := func(name, value string) {
+		if value != "" {
+			fields = append(fields, Field{name, value})
+		}
+	}
+
+	add("ID", t.ID)
+	add("Title", t.Title)
+	add("Status", t.CurrentStatus())
+	add("Priority", t.Priority.String())
+	if t.Project != "" {
+		add("Project", "+"+t.Project)
+	}
+	if t.Category != "" {
+		add("Category", "@"+t.Category)
+	}
+	if len(t.Contexts) > 0 {
+		add("Contexts", "@"+strings.Join(t.Contexts, " @"))
+	}
+	if t.Due != nil {
+		due := t.Due.Format(model.DateLayout)
+		if t.IsOverdue(now) {
+			due += " (overdue)"
+		}
+		add("Due", due)
+	}
+	if t.Scheduled != nil {
+		add("Scheduled", t.Scheduled.Format(model.DateLayout))
+	}
+	add("Repeats", t.Recur)
+	if parent, ok := store.Get(t.ParentID); ok {
+		add("Parent", store.ShortIDs()[parent.ID]+" "+parent.Title)
+	}
+	if sub := store.Descendants(t.ID); len(sub) > 0 {
+		done := 0
+		for _, s := range sub {
+			if s.Done {
+				done++
+			}
+		}
+		add("Subtasks", fmt.Sprintf("%d/%d done", done, len(sub)))
+	}
+	add("Created", t.CreatedAt.Local().Format("2006-01-02 15:04"))
+	if t.CompletedAt != nil {
+		add("Completed", t.CompletedAt.Local().Format("2006-01-02 15:04"))
+	}
+	return fields
+}
+
+func newNotesEditor() textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = "Notes... (- lists, - [ ] checklists, [links](https://...))"
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetWidth(60)
+	ta.SetHeight(10)
+	return ta
+}
+
+// openDetails shows every field of the task under the cursor.
+func (m Model) openDetails() (tea.Model, tea.Cmd) {
+	tasks := m.filteredTasks()
+	if m.cursor >= len(tasks) {
+		return m, nil
+	}
+	m.detailID = tasks[m.cursor].ID
+	m.state = viewingTask
+	return m, nil
+}
+
+// updateDetails handles keys in the detail pane: j/k step through the
+// list without leaving it, e edits the description and space toggles.
+func (m Model) updateDetails(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	t, ok := m.store.Get(m.detailID)
+	if !ok {
+		m.state = browsing
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q", "enter", "h", "left":
+		m.state = browsing
+	case "up", "k", "down", "j":
+		tasks := m.filteredTasks()
+		if msg.String() == "up" || msg.String() == "k" {
+			m.cursor = max(m.cursor-1, 0)
+		} else {
+			m.cursor = min(m.cursor+1, max(len(tasks)-1, 0))
+		}
+		if m.cursor < len(tasks) {
+			m.detailID = tasks[m.cursor].ID
+		}
+	case " ":
+		_, _ = m.store.Toggle(t.ID)
+		_ = m.store.Save()
+	case "e":
+		m.notes.SetValue(t.Description)
+		m.notes.SetWidth(max(m.width-8, 20))
+		m.notes.SetHeight(max(m.height-12, 3))
+		m.state = editingNotes
+		cmd := m.notes.Focus()
+		return m, cmd
+	}
+	return m, nil
+}
+
+// updateNotes handles keys in the description editor. Enter starts a new
+// line, so saving is on ctrl+s.
+func (m Model) updateNotes(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+s":
+		if t, ok := m.store.Get(m.detailID); ok {
+			t.Description = strings.TrimRight(m.notes.Value(), "\n ")
+			_ = m.store.Update(t)
+			_ = m.store.Save()
+			m.statusMsg = "✓ Description saved"
+		}
+		m.notes.Blur()
+		m.state = viewingTask
+		return m, clearStatus()
+	case "esc":
+		m.notes.Blur()
+		m.state = viewingTask
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.notes, cmd = m.notes.Update(msg)
+	return m, cmd
+}
+
+func (m Model) detailsView(lines int) string {
+	t, ok := m.store.Get(m.detailID)
+	if !ok {
+		return helpStyle.Render("This task is gone.")
+	}
+
+	if m.state == editingNotes {
+		return titleStyle.Render("Description · "+t.Title) + "\n\n" + m.notes.View() + "\n\n" +
+			helpStyle.Render("(ctrl+s: save • esc: cancel)")
+	}
+
+	var rows []string
+	for _, f := range Details(m.store, t, time.Now()) {
+		value := f.Value
+		switch f.Name {
+		case "ID":
+			value = dateStyle.Render(value)
+		case "Project":
+			value = projectStyle.Render(value)
+		case "Category", "Contexts":
+			value = categoryStyle.Render(value)
+		case "Due":
+			if t.IsOverdue(time.Now()) {
+				value = overdueStyle.Render(value)
+			} else {
+				value = dueStyle.Render(value)
+			}
+		case "Scheduled":
+			value = scheduledStyle.Render(value)
+		case "Repeats":
+			value = recurStyle.Render(value)
+		}
+		rows = append(rows, fmt.Sprintf("%s %s", detailLabelStyle.Render(f.Name+":"), value))
+	}
+	rows = append(rows, "")
+	if t.Description == "" {
+		rows = append(rows, helpStyle.Render("No description. Press e to write one."))
+	} else {
+		rows = append(rows, strings.Split(RenderMarkdown(t.Description, m.width-8), "\n")...)
+	}
+	if len(rows) > lines {
+		rows = append(rows[:max(lines-1, 1)], helpStyle.Render("…"))
+	}
+
+	content := titleStyle.Render("Task") + "\n\n" + strings.Join(rows, "\n") + "\n"
+	if m.statusMsg != "" {
+		content += "\n" + statusStyle.Render(m.statusMsg)
+	}
+	return content + "\n" + helpStyle.Render("(e: edit description • space: toggle • j/k: next • esc: back)")
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	mdHeading  = regexp.MustCompile(`^#{1,6}\s+(.*)$`)
+	mdCheckbox = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s+(.*)$`)
+	mdBullet   = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdNumbered = regexp.MustCompile(`^(\s*)(\d+[.)])\s+(.*)$`)
+	mdQuote    = regexp.MustCompile(`^>\s?(.*)$`)
+	mdInline   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)|(https?://[^\s)]+)|\*\*([^*]+)\*\*|` + "`([^`]+)`")
+)
+
+// RenderMarkdown renders a task description for the terminal. It knows
+// just enough markdown for notes: # headings, - and 1. lists, - [ ] and
+// - [x] checklists, > quotes, [links](url), bare URLs, **bold** and
+// `code`. Lines are wrapped to width, list items with a hanging indent;
+// a width of 0 leaves them as they are.
+func RenderMarkdown(text string, width int) string {
+	var out []string
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		line = strings.TrimRight(line, " \t")
+		var prefix, body string
+		switch {
+		case mdHeading.MatchString(line):
+			m := mdHeading.FindStringSubmatch(line)
+			out = append(out, wrap("", mdHeadingStyle.Render(m[1]), width))
+			continue
+		case mdCheckbox.MatchString(line):
+			m := mdCheckbox.FindStringSubmatch(line)
+			prefix, body = m[1]+checkboxStyle.Render("☐")+" ", inline(m[3])
+			if m[2] != " " {
+				prefix, body = m[1]+checkedStyle.Render("☑")+" ", doneStyle.Render(m[3])
+			}
+		case mdBullet.MatchString(line):
+			m := mdBullet.FindStringSubmatch(line)
+			prefix, body = m[1]+"• ", inline(m[2])
+		case mdNumbered.MatchString(line):
+			m := mdNumbered.FindStringSubmatch(line)
+			prefix, body = m[1]+m[2]+" ", inline(m[3])
+		case mdQuote.MatchString(line):
+			m := mdQuote.FindStringSubmatch(line)
+			prefix, body = helpStyle.Render("│ "), helpStyle.Render(m[1])
+		default:
+			body = inline(line)
+		}
+		out = append(out, wrap(prefix, body, width))
+	}
+	return strings.Join(out, "\n")
+}
+
+// inline styles the links, bold text and code within a line.
+func inline(s string) string {
+	return mdInline.ReplaceAllStringFunc(s, func(match string) string {
+		m := mdInline.FindStringSubmatch(match)
+		switch {
+		case m[1] != "":
+			return mdLinkStyle.Render(m[1]) + helpStyle.Render(" ("+m[2]+")")
+		case m[3] != "":
+			return mdLinkStyle.Render(m[3])
+		case m[4] != "":
+			return lipgloss.NewStyle().Bold(true).Render(m[4])
+		default:
+			return mdCodeStyle.Render(m[5])
+		}
+	})
+}
+
+// wrap fits prefix+body into width, indenting continuation lines to line
+// up under the body.
+func wrap(prefix, body string, width int) string {
+	indent := lipgloss.Width(prefix)
+	if width <= 0 || width-indent < 10 {
+		return prefix + body
+	}
+	lines := strings.Split(lipgloss.NewStyle().Width(width-indent).Render(body), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+		if i == 0 {
+			lines[i] = prefix + lines[i]
+		} else {
+			lines[i] = strings.Repeat(" ", indent) + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
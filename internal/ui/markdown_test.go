@@ -0,0 +1,35 @@
+package ui
+
+import "testing"
+
+// The tests run without a terminal, so lipgloss renders no colours and
+// only the layout is left to check.
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  string
+	}{
+		{"heading", "## Plan", 0, "Plan"},
+		{"bullets", "- milk\n  * oat", 0, "• milk\n  • oat"},
+		{"numbered", "1. first\n2) second", 0, "1. first\n2) second"},
+		{"checklist", "- [ ] call\n- [x] pay", 0, "☐ call\n☑ pay"},
+		{"quote", "> quoted", 0, "│ quoted"},
+		{"link", "see [docs](https://x.org)", 0, "see docs (https://x.org)"},
+		{"bold and code", "**bold** and `code`", 0, "bold and code"},
+		{"trailing space", "text  \n\n", 0, "text"},
+		{
+			"hanging indent", "- a long list item that needs to wrap across more than one line here", 30,
+			"• a long list item that needs\n  to wrap across more than one\n  line here",
+		},
+		{"too narrow to wrap", "- a long list item", 11, "• a long list item"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMarkdown(tt.text, tt.width); got != tt.want {
+				t.Errorf("RenderMarkdown(%q, %d) =\n%s\nwant\n%s", tt.text, tt.width, got, tt.want)
+			}
+		})
+	}
+}
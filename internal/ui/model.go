@@ -2,14 +2,16 @@ package ui
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"time"
 
-	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"atlas.todo/internal/model"
+	"atlas.todo/internal/query"
 	"atlas.todo/internal/storage"
 )
 
@@ -22,6 +24,16 @@ const (
 	deleting
 	editing
 	showingHelp
+	confirmingChildren
+	switchingList
+	namingView
+	browsingArchive
+	browsingTrash
+	choosingPriority
+	settingCategory
+	viewingTask
+	editingNotes
+	viewingCalendar
 )
 
 type Grouping int
@@ -31,14 +43,19 @@ const (
 	GroupCategory
 	GroupDay
 	GroupPriority
+	GroupProject
 )
 
+
 type Model struct {
-	store        *storage.Store
+	store        storage.Backend
 	cursor       int
 	state        state
 	textInput    textinput.Model
 	searchInput  textinput.Model
+	filter       query.Query // Last search that parsed
+	filterErr    error       // Why the current search text doesn't
+	viewName     string      // Saved view last switched to
 	sortByDate   bool
 	sortAsc      bool
 	showDone     bool
@@ -47,36 +64,68 @@ type Model struct {
 	height       int
 	taskToDelete model.Task
 	taskToEdit   model.Task
+	taskToToggle model.Task
 	statusMsg    string
+	lists        *storage.Lists
+	listName     string
+	listNames    []string
+	listCursor   int
+	archive      storage.Backend // Open while browsing the archive
+	archived     shelf
+	trash        shelf
+	confirmEmpty bool            // Asking before emptying the trash
+	selected     map[string]bool // Marked tasks, by ID
+	anchor       string          // Where a V range starts
+	detailID     string          // Task in the detail pane
+	notes        textarea.Model  // Description editor
+	board        bool            // Showing the tasks as a kanban board
+	calDay       time.Time       // Day selected in the calendar
+	calAgenda    bool            // Calendar shows the agenda, not the month
 	err          error
 }
 
-func NewModel(store *storage.Store) Model {
+// NewModel builds the TUI around store. lists lets the user switch to
+// another named list; listName labels the one that's open: its name, ""
+// for the default list, or the path of a task file that isn't a list.
+func NewModel(store storage.Backend, lists *storage.Lists, listName string) Model {
 	ti := textinput.New()
-	ti.Placeholder = "New task... (e.g. Buy milk @store @urgent !high)"
+	ti.Placeholder = taskPlaceholder
 	ti.Focus()
 	ti.CharLimit = 156
 	ti.Width = 50
 
 	si := textinput.New()
-	si.Placeholder = "Search tasks..."
-	si.CharLimit = 50
-	si.Width = 30
+	si.Placeholder = "Search tasks... (e.g. @work !high due:<7d -done)"
+	si.CharLimit = 200
+	si.Width = 50
 
+	cfg := store.Config()
+	if listName == storage.DefaultList {
+		listName = ""
+	}
 	return Model{
 		store:       store,
+		lists:       lists,
+		listName:    listName,
 		textInput:   ti,
 		searchInput: si,
+		notes:       newNotesEditor(),
 		state:       browsing,
-		sortByDate:  store.Config.SortByDate,
-		sortAsc:     store.Config.SortAsc,
-		showDone:    store.Config.ShowDone,
-		grouping:    Grouping(store.Config.Grouping),
+		sortByDate:  cfg.SortByDate,
+		sortAsc:     cfg.SortAsc,
+		showDone:    cfg.ShowDone,
+		grouping:    Grouping(cfg.Grouping),
 	}
 }
 
+const (
+	taskPlaceholder     = "New task... (e.g. Buy milk @store @urgent !high)"
+	viewPlaceholder     = "View name... (e.g. Today)"
+	categoryPlaceholder = "Category... (empty to clear)"
+)
+
 func (m Model) Init() tea.Cmd {
-	return textinput.Blink
+	return tea.Batch(textinput.Blink, watchFile())
 }
 
 type clearStatusMsg struct{}
@@ -87,6 +136,16 @@ func clearStatus() tea.Cmd {
 	})
 }
 
+type watchFileMsg struct{}
+
+// watchFile schedules the next check for tasks saved by other processes,
+// e.g. an 'atlas.todo add' from another shell.
+func watchFile() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return watchFileMsg{}
+	})
+}
+
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -95,6 +154,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusMsg = ""
 		return m, nil
 
+	case watchFileMsg:
+		changed, err := m.store.Refresh()
+		if err != nil {
+			m.statusMsg = "⚠ Could not reload tasks: " + err.Error()
+			return m, tea.Batch(watchFile(), clearStatus())
+		}
+		if changed {
+			m.statusMsg = "↻ Tasks updated from another session"
+			return m, tea.Batch(watchFile(), clearStatus())
+		}
+		return m, watchFile()
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -103,6 +174,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch m.state {
 		case browsing:
+			if m.board {
+				if next, cmd, ok := m.boardKey(msg); ok {
+					return next, cmd
+				}
+			}
 			switch msg.String() {
 			case "ctrl+c", "q":
 				return m, tea.Quit
@@ -115,27 +191,125 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.cursor < max {
 					m.cursor++
 				}
+			case "enter", "l":
+				return m.openDetails()
+			case "v", "V", "*":
+				return m.mark(msg.String())
+			case "esc":
+				m.clearSelection()
+			case "p":
+				if len(m.targets()) > 0 {
+					m.state = choosingPriority
+				}
+			case "@":
+				targets := m.targets()
+				if len(targets) == 0 {
+					return m, nil
+				}
+				m.state = settingCategory
+				m.textInput.Reset()
+				m.textInput.Placeholder = categoryPlaceholder
+				if len(targets) == 1 {
+					m.textInput.SetValue(targets[0].Category)
+				}
+				m.textInput.Focus()
+				return m, textinput.Blink
 			case " ":
+				if len(m.selected) > 0 {
+					return m.bulkToggle()
+				}
 				tasks := m.filteredTasks()
 				if len(tasks) > 0 && m.cursor < len(tasks) {
-					targetID := tasks[m.cursor].ID
-					for i, t := range m.store.Tasks {
-						if t.ID == targetID {
-							m.store.Toggle(i)
-							break
-						}
+					task := tasks[m.cursor]
+					if !task.Done && len(m.openDescendants(task.ID)) > 0 {
+						m.taskToToggle = task
+						m.state = confirmingChildren
+						return m, nil
+					}
+					return m.toggle(task.ID, nil)
+				}
+			case "b":
+				m.board = !m.board
+				return m, nil
+			case "C":
+				return m.openCalendar()
+			case "J", "ctrl+j":
+				return m.reorder(true)
+			case "K", "ctrl+k":
+				return m.reorder(false)
+			case ">", "tab":
+				tasks := m.filteredTasks()
+				if len(tasks) > 0 && m.cursor < len(tasks) {
+					parent, ok := indentTarget(tasks, m.cursor)
+					if !ok {
+						return m, nil
 					}
+					if parent.Collapsed {
+						parent.Collapsed = false
+						_ = m.store.Update(parent)
+					}
+					_ = m.store.SetParent(tasks[m.cursor].ID, parent.ID)
 					_ = m.store.Save()
 				}
-			case "d":
+			case "<", "shift+tab":
+				tasks := m.filteredTasks()
+				if len(tasks) > 0 && m.cursor < len(tasks) {
+					task := tasks[m.cursor]
+					if task.ParentID == "" {
+						return m, nil
+					}
+					parent, _ := m.store.Get(task.ParentID)
+					_ = m.store.SetParent(task.ID, parent.ParentID)
+					_ = m.store.Save()
+				}
+			case "z":
 				tasks := m.filteredTasks()
 				if len(tasks) > 0 && m.cursor < len(tasks) {
+					task := tasks[m.cursor]
+					if len(m.store.Descendants(task.ID)) == 0 {
+						return m, nil
+					}
+					task.Collapsed = !task.Collapsed
+					_ = m.store.Update(task)
+					_ = m.store.Save()
+				}
+			case "d":
+				tasks := m.filteredTasks()
+				if len(m.selected) > 0 {
+					m.state = deleting
+				} else if len(tasks) > 0 && m.cursor < len(tasks) {
 					m.taskToDelete = tasks[m.cursor]
 					m.state = deleting
 				}
 			case "n":
 				m.state = adding
 				m.textInput.Reset()
+				m.textInput.Placeholder = taskPlaceholder
+				m.textInput.Focus()
+				return m, textinput.Blink
+			case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+				views := m.store.Config().Views
+				n := int(msg.String()[0] - '0')
+				if n > len(views) {
+					m.statusMsg = fmt.Sprintf("No view %d yet (S saves the current one)", n)
+					return m, clearStatus()
+				}
+				m.applyView(views[n-1])
+				return m, nil
+			case "0":
+				m.viewName = ""
+				m.setSearch("")
+				m.cursor = 0
+				return m, nil
+			case "A":
+				return m.openArchive()
+			case "T":
+				return m.openTrash()
+			case "S":
+				m.state = namingView
+				m.textInput.Reset()
+				m.textInput.Placeholder = viewPlaceholder
+				m.textInput.SetValue(m.viewName)
 				m.textInput.Focus()
 				return m, textinput.Blink
 			case "e":
@@ -151,6 +325,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.state = searching
 				m.searchInput.Reset()
 				m.searchInput.Focus()
+				m.filter, m.filterErr = query.Query{}, nil
 				return m, textinput.Blink
 			case "s":
 				if !m.sortByDate {
@@ -161,43 +336,124 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					m.sortByDate = false // Back to Default
 				}
-				m.store.Config.SortByDate = m.sortByDate
-				m.store.Config.SortAsc = m.sortAsc
-				_ = m.store.Save()
+				m.saveConfig()
 				return m, nil
 			case "y":
-				tasks := m.filteredTasks()
-				if len(tasks) > 0 && m.cursor < len(tasks) {
-					task := tasks[m.cursor]
-					content := task.Title
-					if task.Category != "" {
-						content = fmt.Sprintf("%s (@%s)", task.Title, task.Category)
-					}
-					_ = clipboard.WriteAll(content)
-					m.statusMsg = "✓ Copied to clipboard!"
-					return m, clearStatus()
-				}
-				return m, nil
+				return m.yank()
 			case "c":
 				m.showDone = !m.showDone
 				m.cursor = 0
-				m.store.Config.ShowDone = m.showDone
-				_ = m.store.Save()
+				m.saveConfig()
 				return m, nil
 			case "g":
 				m.grouping++
-				if m.grouping > GroupPriority {
+				if m.grouping > GroupProject {
 					m.grouping = GroupNone
 				}
 				m.cursor = 0
-				m.store.Config.Grouping = int(m.grouping)
-				_ = m.store.Save()
+				m.saveConfig()
+				return m, nil
+			case "u":
+				return m.undo(m.store.Undo, "↶ Undid ")
+			case "ctrl+r":
+				return m.undo(m.store.Redo, "↷ Redid ")
+			case "w":
+				if m.lists == nil {
+					return m, nil
+				}
+				names, err := m.lists.Names()
+				if err != nil {
+					m.statusMsg = "⚠ " + err.Error()
+					return m, clearStatus()
+				}
+				m.listNames = names
+				m.listCursor = 0
+				for i, name := range names {
+					if name == m.listName {
+						m.listCursor = i
+					}
+				}
+				m.state = switchingList
 				return m, nil
 			case "h":
 				m.state = showingHelp
 				return m, nil
 			}
 
+		case switchingList:
+			switch msg.String() {
+			case "up", "k":
+				if m.listCursor > 0 {
+					m.listCursor--
+				}
+			case "down", "j":
+				if m.listCursor < len(m.listNames)-1 {
+					m.listCursor++
+				}
+			case "enter":
+				name := m.listNames[m.listCursor]
+				store, err := m.lists.Open(name)
+				m.state = browsing
+				if err != nil {
+					m.statusMsg = "⚠ " + err.Error()
+					return m, clearStatus()
+				}
+				_, _ = storage.PurgeExpired(store, time.Now())
+				m.store = store
+				m.listName = name
+				if name == storage.DefaultList {
+					m.listName = ""
+				}
+				m.cursor = 0
+				m.viewName = ""
+				m.loadConfig()
+				m.statusMsg = "Switched to list: " + name
+				return m, clearStatus()
+			case "esc", "q", "w":
+				m.state = browsing
+			}
+			return m, nil
+
+		case namingView:
+			switch msg.String() {
+			case "enter":
+				name := strings.TrimSpace(m.textInput.Value())
+				if name != "" {
+					cfg := m.store.Config()
+					cfg.SetView(m.currentView(name))
+					m.store.SetConfig(cfg)
+					_ = m.store.Save()
+					m.viewName = name
+					for i, v := range cfg.Views {
+						if v.Name == name && i < 9 {
+							m.statusMsg = fmt.Sprintf("✓ Saved view %q (press %d)", name, i+1)
+						}
+					}
+				}
+				m.state = browsing
+				return m, clearStatus()
+			case "esc":
+				m.state = browsing
+				return m, nil
+			}
+			m.textInput, cmd = m.textInput.Update(msg)
+			return m, cmd
+
+		case browsingArchive:
+			return m.updateArchive(msg)
+
+		case browsingTrash:
+			return m.updateTrash(msg)
+
+		case viewingTask:
+			return m.updateDetails(msg)
+
+		case editingNotes:
+			return m.updateNotes(msg)
+
+		case viewingCalendar:
+			return m.updateCalendar(msg)
+
 		case showingHelp:
 			switch msg.String() {
 			case "esc", "q", "h":
@@ -232,25 +488,60 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			m.searchInput, cmd = m.searchInput.Update(msg)
+			// Keep filtering by the last query that parsed while one is
+			// half-typed, and show what's wrong with it
+			if q, err := query.Parse(m.searchInput.Value(), time.Now()); err != nil {
+				m.filterErr = err
+			} else {
+				m.filter, m.filterErr = q, nil
+			}
+			return m, cmd
+
+		case confirmingChildren:
+			switch msg.String() {
+			case "y", "Y", "enter":
+				return m.toggle(m.taskToToggle.ID, m.openDescendants(m.taskToToggle.ID))
+			case "n", "N":
+				return m.toggle(m.taskToToggle.ID, nil)
+			case "esc", "q":
+				m.state = browsing
+				return m, nil
+			}
+
+		case choosingPriority:
+			m.state = browsing
+			if p, ok := model.ParsePriority(map[string]string{"h": "high", "m": "med", "l": "low"}[msg.String()]); ok {
+				return m.bulkUpdate("priority "+p.String(), func(t *model.Task) { t.Priority = p })
+			}
+			return m, nil
+
+		case settingCategory:
+			switch msg.String() {
+			case "enter":
+				category := strings.TrimPrefix(strings.TrimSpace(m.textInput.Value()), "@")
+				return m.bulkUpdate("category", func(t *model.Task) { t.Category = category })
+			case "esc":
+				m.state = browsing
+				return m, nil
+			}
+			m.textInput, cmd = m.textInput.Update(msg)
 			return m, cmd
 
 		case deleting:
 			switch msg.String() {
 			case "y", "Y", "enter":
-				targetID := m.taskToDelete.ID
-				for i, t := range m.store.Tasks {
-					if t.ID == targetID {
-						m.store.Delete(i)
-						break
-					}
+				if len(m.selected) > 0 {
+					return m.bulkDelete()
 				}
+				_ = m.store.Delete(m.taskToDelete.ID)
 				_ = m.store.Save()
 				m.state = browsing
 				tasks := m.filteredTasks()
 				if m.cursor >= len(tasks) && m.cursor > 0 {
 					m.cursor--
 				}
-				return m, nil
+				m.statusMsg = "🗑 Moved to trash (T to browse it)"
+				return m, clearStatus()
 			case "n", "N", "esc", "q":
 				m.state = browsing
 				return m, nil
@@ -261,14 +552,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "enter":
 				text := m.textInput.Value()
 				if text != "" {
-					updatedTask := model.ParseTask(text)
-					for i, t := range m.store.Tasks {
-						if t.ID == m.taskToEdit.ID {
-							m.store.Tasks[i].Title = updatedTask.Title
-							m.store.Tasks[i].Category = updatedTask.Category
-							m.store.Tasks[i].Priority = updatedTask.Priority
-							break
-						}
+					if task, ok := m.store.Get(m.taskToEdit.ID); ok {
+						_ = m.store.Update(task.Reparse(text))
 					}
 					_ = m.store.Save()
 				}
@@ -286,20 +571,75 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m Model) filteredTasks() []model.Task {
-	var filtered []model.Task
-	query := strings.ToLower(m.searchInput.Value())
+// toggle flips the completion of a task and of the given subtasks, then
+// saves. Completing a recurring task reports when the next one is due.
+func (m Model) toggle(id string, subtasks []string) (tea.Model, tea.Cmd) {
+	m.state = browsing
+
+	next, _ := m.store.Toggle(id)
+	for _, sub := range subtasks {
+		_, _ = m.store.Toggle(sub)
+	}
+	_ = m.store.Save()
+
+	if next != nil {
+		m.statusMsg = "↻ Next due " + formatDue(*next.Due, time.Now())
+		return m, clearStatus()
+	}
+	return m, nil
+}
+
+// undo runs Undo or Redo, saves, and reports what changed. The view
+// settings are reloaded since they may have been part of the change.
+func (m Model) undo(op func() (string, error), verb string) (tea.Model, tea.Cmd) {
+	what, err := op()
+	if err != nil {
+		m.statusMsg = "⚠ " + err.Error()
+		return m, clearStatus()
+	}
+	_ = m.store.Save()
+	m.loadConfig()
+
+	m.statusMsg = verb + what
+	return m, clearStatus()
+}
 
-	for _, t := range m.store.Tasks {
-		// Filter by 'showDone'
-		if !m.showDone && t.Done {
-			continue
+// loadConfig takes the view settings from the store.
+func (m *Model) loadConfig() {
+	cfg := m.store.Config()
+	m.sortByDate = cfg.SortByDate
+	m.sortAsc = cfg.SortAsc
+	m.showDone = cfg.ShowDone
+	m.grouping = Grouping(cfg.Grouping)
+}
+
+// saveConfig persists the view settings so the next session starts the same
+// way.
+func (m Model) saveConfig() {
+	cfg := m.store.Config()
+	cfg.ShowDone = m.showDone
+	cfg.SortByDate = m.sortByDate
+	cfg.SortAsc = m.sortAsc
+	cfg.Grouping = int(m.grouping)
+	m.store.SetConfig(cfg)
+	_ = m.store.Save()
+}
+
+// matchingTasks returns the tasks the list would show, in store order and
+// without nesting, so none are hidden under a collapsed parent.
+func (m Model) matchingTasks() []model.Task {
+	return m.store.Query(func(t model.Task) bool {
+		// Filter by 'showDone', unless the search says which to show
+		if !m.showDone && t.Done && !m.filter.MentionsDone() {
+			return false
 		}
 		// Filter by search query
-		if query == "" || strings.Contains(strings.ToLower(t.Title), query) {
-			filtered = append(filtered, t)
-		}
-	}
+		return m.filter.Match(t)
+	})
+}
+
+func (m Model) filteredTasks() []model.Task {
+	filtered := m.matchingTasks()
 
 	// 1. Grouping Sorts
 	switch m.grouping {
@@ -321,8 +661,8 @@ func (m Model) filteredTasks() []model.Task {
 		})
 	case GroupDay:
 		sort.SliceStable(filtered, func(i, j int) bool {
-			d1 := filtered[i].CreatedAt.Format("2006-01-02")
-			d2 := filtered[j].CreatedAt.Format("2006-01-02")
+			d1 := filtered[i].Day().Format("2006-01-02")
+			d2 := filtered[j].Day().Format("2006-01-02")
 			if m.sortAsc {
 				return d1 < d2
 			}
@@ -335,6 +675,14 @@ func (m Model) filteredTasks() []model.Task {
 			}
 			return filtered[i].Priority > filtered[j].Priority
 		})
+	case GroupProject:
+		sort.SliceStable(filtered, func(i, j int) bool {
+			p1, p2 := m.groupKey(filtered[i]), m.groupKey(filtered[j])
+			if m.sortAsc {
+				return p1 < p2
+			}
+			return p1 > p2
+		})
 	}
 
 	// 2. Date Sort
@@ -347,7 +695,36 @@ func (m Model) filteredTasks() []model.Task {
 		})
 	}
 
-	return filtered
+	// 3. Hierarchy
+	return nest(filtered)
+}
+
+// groupKey is the heading a task is listed under in the current grouping.
+func (m Model) groupKey(t model.Task) string {
+	switch m.grouping {
+	case GroupCategory:
+		if t.Category == "" {
+			return "Uncategorized"
+		}
+		return t.Category
+	case GroupDay:
+		return t.Day().Format("Monday, 02 Jan 2006")
+	case GroupPriority:
+		switch t.Priority {
+		case model.PriorityHigh:
+			return "!!! High Priority"
+		case model.PriorityMedium:
+			return "!!  Medium Priority"
+		case model.PriorityLow:
+			return "!   Low Priority"
+		}
+	case GroupProject:
+		if t.Project == "" {
+			return "No Project"
+		}
+		return "+" + t.Project
+	}
+	return ""
 }
 
 func (m Model) View() string {
@@ -374,21 +751,88 @@ func (m Model) View() string {
 		))
 	}
 
+	if m.state == settingCategory {
+		return style.Render(fmt.Sprintf(
+			"Set the category of %d task(s):\n\n%s\n\n(esc to cancel, enter to save)",
+			len(m.targets()), m.textInput.View(),
+		))
+	}
+
+	if m.state == namingView {
+		return style.Render(fmt.Sprintf(
+			"Save the current search and view settings as:\n\n%s\n\n(esc to cancel, enter to save; keys 1-9 switch views)",
+			m.textInput.View(),
+		))
+	}
+
+	if m.state == browsingArchive {
+		return style.PaddingTop(topPad).Render(m.archiveView(m.height - 8 - topPad - botPad))
+	}
+
+	if m.state == browsingTrash {
+		return style.PaddingTop(topPad).Render(m.trashView(m.height - 8 - topPad - botPad))
+	}
+
+	if m.state == viewingCalendar {
+		return style.PaddingTop(topPad).Render(m.calendarView(m.height - 8 - topPad - botPad))
+	}
+
+	if m.state == viewingTask || m.state == editingNotes {
+		return style.PaddingTop(topPad).Render(m.detailsView(m.height - 8 - topPad - botPad))
+	}
+
+	if m.state == switchingList {
+		content := titleStyle.Render("Switch list") + "\n\n"
+		for i, name := range m.listNames {
+			line := "  " + name
+			if i == m.listCursor {
+				line = cursorStyle.Render("❯ ") + name
+			}
+			if name == m.listName || (name == storage.DefaultList && m.listName == "") {
+				line += helpStyle.Render(" (open)")
+			}
+			content += line + "\n"
+		}
+		content += "\n" + helpStyle.Render("(enter to open, esc to cancel; create lists with atlas.todo --list <name>)")
+		return style.PaddingTop(topPad).Render(content)
+	}
+
 	if m.state == showingHelp {
 		content := titleStyle.Render("Atlas Todo - Help & Tutorial") + "\n\n"
 		
 		content += groupHeaderStyle.Render("Metadata Basics") + "\n"
 		content += "  • Category: Use @ (e.g., \"Buy milk @grocery\")\n"
+		content += "  • Contexts: Further @words (e.g., \"Call mom @phone @home @evening\")\n"
+		content += "  • Project: Use + (e.g., \"Write docs +atlas\")\n"
 		content += "  • Priority: Use ! (e.g., \"Fix bug !high\", \"!low\")\n"
-		content += "  • Multiple: \"Meet John @work !medium\"\n\n"
+		content += "  • Due date: Use due: (e.g., \"due:fri\", \"due:+3d\", \"due:2026-11-01\")\n"
+		content += "  • Scheduled: Use sched: for the day you plan to do it (e.g., \"sched:mon\")\n"
+		content += "  • Repeat: Use rec: (e.g., \"rec:daily\", \"rec:weekly:mon\", \"rec:monthly:15\", \"rec:+2w\")\n"
+		content += "  • Multiple: \"Meet John @work !medium due:tomorrow\"\n\n"
 		
 		content += groupHeaderStyle.Render("Commands") + "\n"
 		content += "  ↑/↓, j/k: move cursor • space: toggle done\n"
 		content += "  n: new task      • e: edit selected\n"
-		content += "  d: delete task   • y: copy to clipboard\n"
+		content += "  enter/l: details and description\n"
+		content += "  d: trash task    • y: copy to clipboard\n"
 		content += "  s: cycle sort    • c: toggle completed\n"
-		content += "  g: cycle groups  • /: search tasks\n"
-		content += "  h: toggle help   • q: quit\n\n"
+		content += "  g: cycle groups  • /: search (e.g. @work !high due:<7d -done)\n"
+		content += "  >/tab: indent    • </shift+tab: outdent\n"
+		content += "  J/K: move task down/up in its group\n"
+		content += "  b: kanban board  • ←/→: switch column (board)\n"
+		content += "  H/L: move card to the previous/next column (board)\n"
+		content += "  C: calendar and agenda\n"
+		content += "  z: fold subtasks • u: undo\n"
+		content += "  ctrl+r: redo     • w: switch list\n"
+		content += "  1-9: open view   • S: save as view\n"
+		content += "  0: leave view    • A: browse archive\n"
+		content += "  T: browse trash  • p: set priority\n"
+		content += "  @: set category  • h: toggle help\n"
+		content += "  q: quit\n\n"
+		content += groupHeaderStyle.Render("Marking Several Tasks") + "\n"
+		content += "  v: mark task     • V: mark range from last v\n"
+		content += "  *: mark shown    • esc: clear marks\n"
+		content += "  space, d, p, @ and y then act on every marked task\n\n"
 
 		content += helpStyle.Render("(press h or esc to return)")
 		
@@ -397,6 +841,15 @@ func (m Model) View() string {
 
 	// 3. Header Construction
 	statusParts := []string{}
+	if label := m.viewLabel(); label != "" {
+		statusParts = append(statusParts, "View: "+label)
+	}
+	if m.board {
+		statusParts = append(statusParts, "Board")
+	}
+	if len(m.selected) > 0 {
+		statusParts = append(statusParts, fmt.Sprintf("Selected: %d", len(m.selected)))
+	}
 	if m.sortByDate {
 		orderStr := "↑"
 		if !m.sortAsc { orderStr = "↓" }
@@ -407,6 +860,7 @@ func (m Model) View() string {
 	case GroupCategory: statusParts = append(statusParts, "Group: Category")
 	case GroupDay: statusParts = append(statusParts, "Group: Day")
 	case GroupPriority: statusParts = append(statusParts, "Group: Priority")
+	case GroupProject: statusParts = append(statusParts, "Group: Project")
 	}
 	
 	statusStr := ""
@@ -419,19 +873,46 @@ func (m Model) View() string {
 		}
 	}
 
-	headerText := titleStyle.Render("Atlas Todo") + statusStr
+	title := "Atlas Todo"
+	if m.listName != "" {
+		title += " · " + m.listName
+	}
+	headerText := titleStyle.Render(title) + statusStr
 	headerLines := 1
 	
 	searchBar := ""
 	if m.state == searching || m.searchInput.Value() != "" {
 		searchBar = "\nSearch: " + m.searchInput.View()
 		headerLines++
+		if m.filterErr != nil {
+			searchBar += "\n" + deleteWarnStyle.Render("⚠ "+m.filterErr.Error())
+			headerLines++
+		}
 	}
 	headerText += searchBar + "\n" // Final newline
 	headerLines++
 
+	if m.state == choosingPriority {
+		prompt := fmt.Sprintf("Priority for %d task(s): h) high  m) med  l) low  (esc to cancel)", len(m.targets()))
+		return appStyle.Render(headerText + "\n" + deleteWarnStyle.Render(prompt))
+	}
+
+	if m.state == deleting && len(m.selected) > 0 {
+		prompt := fmt.Sprintf("Move %d selected task(s) to the trash? (y/n)", len(m.selected))
+		return appStyle.Render(headerText + "\n" + deleteWarnStyle.Render(prompt))
+	}
+
 	if m.state == deleting {
-		prompt := fmt.Sprintf("Delete \"%s\"? (y/n)", m.taskToDelete.Title)
+		prompt := fmt.Sprintf("Move \"%s\" to the trash? (y/n)", m.taskToDelete.Title)
+		if n := len(m.store.Descendants(m.taskToDelete.ID)); n > 0 {
+			prompt = fmt.Sprintf("Move \"%s\" to the trash? Its %d subtask(s) move up a level. (y/n)", m.taskToDelete.Title, n)
+		}
+		return appStyle.Render(headerText + "\n" + deleteWarnStyle.Render(prompt))
+	}
+
+	if m.state == confirmingChildren {
+		n := len(m.openDescendants(m.taskToToggle.ID))
+		prompt := fmt.Sprintf("\"%s\" has %d open subtask(s). Complete them too? (y/n, esc to cancel)", m.taskToToggle.Title, n)
 		return appStyle.Render(headerText + "\n" + deleteWarnStyle.Render(prompt))
 	}
 
@@ -452,6 +933,10 @@ func (m Model) View() string {
 	if len(displayTasks) > 0 && m.cursor >= len(displayTasks) {
 		m.cursor = len(displayTasks) - 1
 	}
+	if m.board && len(displayTasks) > 0 {
+		return style.Render(strings.Repeat("\n", topPad) + headerText + m.boardView(displayTasks, lineBudget) + m.footer(showStatus, showHelpLine))
+	}
+	depths, roots := layout(displayTasks)
 
 	// 5. Robust Scroll / Offset Calculation
 	startIdx := m.cursor - (lineBudget / 2)
@@ -464,18 +949,7 @@ func (m Model) View() string {
 		
 		tempLastGroup := ""
 		if startIdx > 0 && m.grouping != GroupNone {
-			prevTask := displayTasks[startIdx-1]
-			switch m.grouping {
-			case GroupCategory: tempLastGroup = prevTask.Category
-			case GroupDay: tempLastGroup = prevTask.CreatedAt.Format("Monday, 02 Jan 2006")
-			case GroupPriority:
-				switch prevTask.Priority {
-				case model.PriorityHigh: tempLastGroup = "!!! High Priority"
-				case model.PriorityMedium: tempLastGroup = "!!  Medium Priority"
-				case model.PriorityLow: tempLastGroup = "!   Low Priority"
-				}
-			}
-			if tempLastGroup == "" && m.grouping == GroupCategory { tempLastGroup = "Uncategorized" }
+			tempLastGroup = m.groupKey(roots[startIdx-1])
 		}
 
 		cursorReached := false
@@ -484,18 +958,7 @@ func (m Model) View() string {
 			if i < len(displayTasks)-1 { tBudget-- } // reserve for hidden below
 
 			if m.grouping != GroupNone {
-				gKey := ""
-				switch m.grouping {
-				case GroupCategory: gKey = displayTasks[i].Category
-					if gKey == "" { gKey = "Uncategorized" }
-				case GroupDay: gKey = displayTasks[i].CreatedAt.Format("Monday, 02 Jan 2006")
-				case GroupPriority:
-					switch displayTasks[i].Priority {
-					case model.PriorityHigh: gKey = "!!! High Priority"
-					case model.PriorityMedium: gKey = "!!  Medium Priority"
-					case model.PriorityLow: gKey = "!   Low Priority"
-					}
-				}
+				gKey := m.groupKey(roots[i])
 				if gKey != tempLastGroup {
 					if linesNeeded > 0 { linesNeeded++ } // newline
 					linesNeeded++ // header
@@ -526,20 +989,10 @@ func (m Model) View() string {
 
 	var lastGroupKey string
 	if startIdx > 0 && m.grouping != GroupNone {
-		prevTask := displayTasks[startIdx-1]
-		switch m.grouping {
-		case GroupCategory: lastGroupKey = prevTask.Category
-		case GroupDay: lastGroupKey = prevTask.CreatedAt.Format("Monday, 02 Jan 2006")
-		case GroupPriority:
-			switch prevTask.Priority {
-			case model.PriorityHigh: lastGroupKey = "!!! High Priority"
-			case model.PriorityMedium: lastGroupKey = "!!  Medium Priority"
-			case model.PriorityLow: lastGroupKey = "!   Low Priority"
-			}
-		}
-		if lastGroupKey == "" && m.grouping == GroupCategory { lastGroupKey = "Uncategorized" }
+		lastGroupKey = m.groupKey(roots[startIdx-1])
 	}
 
+	now := time.Now()
 	lastTaskIdx := startIdx - 1
 	for i := startIdx; i < len(displayTasks); i++ {
 		// Reserve 1 line for "hidden below" if not at the end
@@ -552,20 +1005,7 @@ func (m Model) View() string {
 		
 		// Handle Grouping
 		if m.grouping != GroupNone {
-			currentGroupKey := ""
-			switch m.grouping {
-			case GroupCategory:
-				currentGroupKey = task.Category
-				if currentGroupKey == "" { currentGroupKey = "Uncategorized" }
-			case GroupDay:
-				currentGroupKey = task.CreatedAt.Format("Monday, 02 Jan 2006")
-			case GroupPriority:
-				switch task.Priority {
-				case model.PriorityHigh: currentGroupKey = "!!! High Priority"
-				case model.PriorityMedium: currentGroupKey = "!!  Medium Priority"
-				case model.PriorityLow: currentGroupKey = "!   Low Priority"
-				}
-			}
+			currentGroupKey := m.groupKey(roots[i])
 			
 			if currentGroupKey != lastGroupKey {
 				// No space for newline/header/task combo? 
@@ -594,26 +1034,59 @@ func (m Model) View() string {
 
 			cursor := " "
 			if m.cursor == i { cursor = cursorStyle.Render("❯") }
+			mark := " "
+			if m.selected[task.ID] { mark = markStyle.Render("●") }
+			indent := strings.Repeat("  ", depths[i])
 
 			checked := checkboxStyle.Render("☐")
 			if task.Done { checked = checkedStyle.Render("☑") }
 
 			catStr := ""
 			if task.Category != "" { catStr = fmt.Sprintf(" (@%s)", task.Category) }
+			projStr := ""
+			if task.Project != "" { projStr = " +" + task.Project }
+			ctxStr := ""
+			for _, c := range task.Contexts { ctxStr += " @" + c }
+			dueStr := ""
+			if task.Due != nil { dueStr = " due " + formatDue(*task.Due, now) }
+			schedStr := ""
+			if task.Scheduled != nil { schedStr = " sched " + formatDue(*task.Scheduled, now) }
+			recStr := ""
+			if task.Recur != "" { recStr = " ↻ " + task.Recur }
+			subStr := ""
+			if done, total := m.progress(task.ID); total > 0 {
+				subStr = fmt.Sprintf(" [%d/%d]", done, total)
+				if task.Collapsed { subStr = " ▸" + subStr }
+			}
 			dateStr := task.CreatedAt.Format(" (2006-01-02 15:04)")
 			
-			var titlePart, catPart, datePart string
+			var titlePart, projPart, catPart, ctxPart, duePart, schedPart, recPart, subPart, datePart string
 			if task.Done {
 				titlePart = doneStyle.Render(task.Title)
+				projPart = doneStyle.Render(projStr)
 				catPart = doneStyle.Render(catStr)
+				ctxPart = doneStyle.Render(ctxStr)
+				duePart = doneStyle.Render(dueStr)
+				schedPart = doneStyle.Render(schedStr)
+				recPart = doneStyle.Render(recStr)
+				subPart = doneStyle.Render(subStr)
 				datePart = doneStyle.Render(dateStr)
 			} else {
 				titlePart = task.Title
+				projPart = projectStyle.Render(projStr)
 				catPart = categoryStyle.Render(catStr)
+				ctxPart = contextStyle.Render(ctxStr)
+				duePart = dueStyle.Render(dueStr)
+				if task.IsOverdue(now) {
+					duePart = overdueStyle.Render(dueStr)
+				}
+				schedPart = scheduledStyle.Render(schedStr)
+				recPart = recurStyle.Render(recStr)
+				subPart = progressStyle.Render(subStr)
 				datePart = dateStyle.Render(dateStr)
 			}
 
-			content := fmt.Sprintf("%s %s %s%s%s", cursor, checked, titlePart, catPart, datePart)
+			content := fmt.Sprintf("%s%s%s%s %s%s%s%s%s%s%s%s%s", cursor, mark, indent, checked, titlePart, subPart, projPart, catPart, ctxPart, duePart, schedPart, recPart, datePart)
 			s += baseStyle.Render(content) + "\n"
 			linesUsed++
 			lastTaskIdx = i
@@ -634,17 +1107,34 @@ func (m Model) View() string {
 		s = "\n  No tasks found.\n"
 	}
 
-	// 7. Footer Construction
+	// 7. Final Assembly
+	res := strings.Repeat("\n", topPad) + headerText + s + m.footer(showStatus, showHelpLine)
+	return style.Render(res)
+}
+
+// footer is the status message and help hint under the list.
+func (m Model) footer(showStatus, showHelpLine bool) string {
 	footer := ""
 	if showStatus { footer += "\n" + statusStyle.Render(m.statusMsg) }
 	
 	if showHelpLine {
 		footer += "\n" + helpStyle.Render("h: help")
 	}
-
-	// 8. Final Assembly
-	res := strings.Repeat("\n", topPad) + headerText + s + footer
-	return style.Render(res)
+	return footer
 }
 
-	
\ No newline at end of file
+// formatDue renders a due date relative to today where that reads better.
+func formatDue(due, now time.Time) string {
+	days := int(math.Round(model.StartOfDay(due).Sub(model.StartOfDay(now)).Hours() / 24))
+	switch {
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "tomorrow"
+	case days == -1:
+		return "yesterday"
+	case days > 1 && days < 7:
+		return due.Format("Mon")
+	}
+	return due.Format("2006-01-02")
+}
@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"atlas.todo/internal/model"
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// targets returns the tasks a bulk action applies to: the marked ones, or
+// the one under the cursor when nothing is marked.
+func (m Model) targets() []model.Task {
+	if len(m.selected) > 0 {
+		return m.store.Query(func(t model.Task) bool { return m.selected[t.ID] })
+	}
+	tasks := m.filteredTasks()
+	if m.cursor < len(tasks) {
+		return tasks[m.cursor : m.cursor+1]
+	}
+	return nil
+}
+
+// mark handles the selection keys: v marks or unmarks the task under the
+// cursor, V marks every row from the last v (or V) to the cursor, and *
+// marks everything the current filter shows, or unmarks it if it already
+// was.
+func (m Model) mark(key string) (tea.Model, tea.Cmd) {
+	tasks := m.filteredTasks()
+	if m.cursor >= len(tasks) {
+		return m, nil
+	}
+	if m.selected == nil {
+		m.selected = map[string]bool{}
+	}
+	current := tasks[m.cursor].ID
+
+	switch key {
+	case "v":
+		if m.selected[current] {
+			delete(m.selected, current)
+		} else {
+			m.selected[current] = true
+		}
+		m.anchor = current
+	case "V":
+		from := -1
+		for i, t := range tasks {
+			if t.ID == m.anchor {
+				from = i
+			}
+		}
+		if from < 0 {
+			m.anchor = current
+			m.selected[current] = true
+			m.statusMsg = "Move, then press V again to mark the range"
+			return m, clearStatus()
+		}
+		for i := min(from, m.cursor); i <= max(from, m.cursor); i++ {
+			m.selected[tasks[i].ID] = true
+		}
+		m.anchor = ""
+	case "*":
+		all := true
+		for _, t := range tasks {
+			all = all && m.selected[t.ID]
+		}
+		for _, t := range tasks {
+			if all {
+				delete(m.selected, t.ID)
+			} else {
+				m.selected[t.ID] = true
+			}
+		}
+	}
+	return m, nil
+}
+
+// clearSelection unmarks everything.
+func (m *Model) clearSelection() {
+	m.selected, m.anchor = nil, ""
+}
+
+// bulkToggle completes every marked task, or reopens them all if they're
+// all done already.
+func (m Model) bulkToggle() (tea.Model, tea.Cmd) {
+	targets := m.targets()
+	done := true
+	for _, t := range targets {
+		done = done && t.Done
+	}
+
+	n, spawned := 0, 0
+	for _, t := range targets {
+		if t.Done != done {
+			continue // Already where we're taking everything
+		}
+		next, err := m.store.Toggle(t.ID)
+		if err == nil {
+			n++
+		}
+		if next != nil {
+			spawned++
+		}
+	}
+	_ = m.store.Save()
+	m.clearSelection()
+	m.clampCursor()
+
+	verb := "Completed"
+	if done {
+		verb = "Reopened"
+	}
+	m.statusMsg = fmt.Sprintf("✓ %s %d task(s)", verb, n)
+	if spawned > 0 {
+		m.statusMsg += fmt.Sprintf(", %d repeat(s) scheduled", spawned)
+	}
+	return m, clearStatus()
+}
+
+// bulkDelete moves every marked task to the trash.
+func (m Model) bulkDelete() (tea.Model, tea.Cmd) {
+	n := 0
+	for _, t := range m.targets() {
+		if m.store.Delete(t.ID) == nil {
+			n++
+		}
+	}
+	_ = m.store.Save()
+	m.clearSelection()
+	m.state = browsing
+	m.clampCursor()
+	m.statusMsg = fmt.Sprintf("🗑 Moved %d task(s) to trash (T to browse it)", n)
+	return m, clearStatus()
+}
+
+// bulkUpdate applies change to every target and saves.
+func (m Model) bulkUpdate(what string, change func(*model.Task)) (tea.Model, tea.Cmd) {
+	targets := m.targets()
+	for _, t := range targets {
+		change(&t)
+		_ = m.store.Update(t)
+	}
+	_ = m.store.Save()
+	m.state = browsing
+	m.statusMsg = fmt.Sprintf("✓ Set %s on %d task(s)", what, len(targets))
+	return m, clearStatus()
+}
+
+// yank copies the targets' titles to the clipboard, one per line.
+func (m Model) yank() (tea.Model, tea.Cmd) {
+	var lines []string
+	for _, t := range m.targets() {
+		line := t.Title
+		if t.Category != "" {
+			line = fmt.Sprintf("%s (@%s)", t.Title, t.Category)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return m, nil
+	}
+	_ = clipboard.WriteAll(strings.Join(lines, "\n"))
+	m.statusMsg = "✓ Copied to clipboard!"
+	if len(lines) > 1 {
+		m.statusMsg = fmt.Sprintf("✓ Copied %d titles to clipboard!", len(lines))
+	}
+	return m, clearStatus()
+}
+
+// clampCursor keeps the cursor on the list after tasks drop out of it.
+func (m *Model) clampCursor() {
+	if tasks := m.filteredTasks(); m.cursor >= len(tasks) {
+		m.cursor = max(len(tasks)-1, 0)
+	}
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"atlas.todo/internal/model"
+	"atlas.todo/internal/storage"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// press sends keys to m as if they were typed: named keys like "esc" and
+// "enter", or runes.
+func press(m Model, keys ...string) Model {
+	named := map[string]tea.KeyType{
+		"esc": tea.KeyEsc, "enter": tea.KeyEnter, "tab": tea.KeyTab, " ": tea.KeySpace,
+		"up": tea.KeyUp, "down": tea.KeyDown, "left": tea.KeyLeft, "right": tea.KeyRight,
+	}
+	for _, k := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		if t, ok := named[k]; ok {
+			msg = tea.KeyMsg{Type: t}
+		}
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+	return m
+}
+
+// newTestModel opens the TUI on a store holding tasks titled a, b, c, ...
+func newTestModel(titles ...string) (Model, *storage.MemoryStore) {
+	store := storage.NewMemoryStore()
+	for _, title := range titles {
+		store.Add(model.Task{ID: title, Title: title})
+	}
+	return NewModel(store, nil, ""), store
+}
+
+func TestMark(t *testing.T) {
+	tests := []struct {
+		keys []string
+		want []string
+	}{
+		{[]string{"v"}, []string{"a"}},
+		{[]string{"v", "v"}, nil},
+		{[]string{"v", "j", "j", "v"}, []string{"a", "c"}},
+		{[]string{"j", "v", "j", "j", "V"}, []string{"b", "c", "d"}},
+		{[]string{"j", "j", "j", "v", "k", "k", "V"}, []string{"b", "c", "d"}},
+		{[]string{"V"}, []string{"a"}}, // Starts a range
+		{[]string{"*"}, []string{"a", "b", "c", "d"}},
+		{[]string{"v", "*"}, []string{"a", "b", "c", "d"}},
+		{[]string{"*", "*"}, nil},
+		{[]string{"*", "esc"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.keys), func(t *testing.T) {
+			m, _ := newTestModel("a", "b", "c", "d")
+			m = press(m, tt.keys...)
+			var got []string
+			for id, ok := range m.selected {
+				if ok {
+					got = append(got, id)
+				}
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("marked %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkActions(t *testing.T) {
+	m, store := newTestModel("a", "b", "c")
+	m = press(m, "v", "j", "v", " ")
+	if len(m.selected) != 0 {
+		t.Error("the marks outlived the action")
+	}
+	var done []string
+	for _, task := range store.Query(func(t model.Task) bool { return t.Done }) {
+		done = append(done, task.ID)
+	}
+	if !slices.Equal(done, []string{"a", "b"}) {
+		t.Fatalf("done: %q, want [a b]", done)
+	}
+
+	// Marked tasks that are all done are reopened
+	m.showDone = true
+	m = press(m, "k", "v", "j", "v", " ")
+	if n := len(store.Query(func(t model.Task) bool { return t.Done })); n != 0 {
+		t.Errorf("%d task(s) still done, want them reopened", n)
+	}
+
+	m = press(m, "*", "p", "h")
+	for _, task := range store.Query(nil) {
+		if task.Priority != model.PriorityHigh {
+			t.Errorf("%s has priority %v, want high", task.ID, task.Priority)
+		}
+	}
+
+	press(m, "esc", "v", "d", "y")
+	if got := len(store.Query(nil)); got != 2 {
+		t.Errorf("%d task(s) left after deleting one, want 2", got)
+	}
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fmt"
+
+	"atlas.todo/internal/model"
+)
+
+// shelf is a read-only list of tasks put away somewhere, like the archive
+// or the trash, that the user can browse and take tasks back from.
+type shelf struct {
+	tasks  []model.Task
+	cursor int
+}
+
+func (s *shelf) set(tasks []model.Task) {
+	s.tasks = tasks
+	s.cursor = min(s.cursor, max(len(tasks)-1, 0))
+}
+
+func (s *shelf) move(key string) {
+	switch key {
+	case "up", "k":
+		if s.cursor > 0 {
+			s.cursor--
+		}
+	case "down", "j":
+		if s.cursor < len(s.tasks)-1 {
+			s.cursor++
+		}
+	}
+}
+
+// selected returns the task under the cursor.
+func (s shelf) selected() (model.Task, bool) {
+	if s.cursor >= len(s.tasks) {
+		return model.Task{}, false
+	}
+	return s.tasks[s.cursor], true
+}
+
+// view renders the shelf in lines rows, windowed around the cursor. stamp
+// labels each task, e.g. with when it was put away.
+func (s shelf) view(title, empty string, lines int, stamp func(model.Task) string) string {
+	content := titleStyle.Render(fmt.Sprintf("%s · %d task(s)", title, len(s.tasks))) + "\n\n"
+	if len(s.tasks) == 0 {
+		content += helpStyle.Render("  "+empty) + "\n"
+	}
+
+	lines = max(lines, 1)
+	start := max(0, min(s.cursor-lines/2, len(s.tasks)-lines))
+	end := min(len(s.tasks), start+lines)
+	for i := start; i < end; i++ {
+		t := s.tasks[i]
+		line := t.Title
+		if t.Done {
+			line = checkedStyle.Render("☑") + " " + doneStyle.Render(t.Title)
+		} else {
+			line = checkboxStyle.Render("☐") + " " + line
+		}
+		if t.Project != "" {
+			line += " " + projectStyle.Render("+"+t.Project)
+		}
+		if t.Category != "" {
+			line += " " + categoryStyle.Render("(@"+t.Category+")")
+		}
+		line += " " + dateStyle.Render(stamp(t))
+
+		if i == s.cursor {
+			content += cursorStyle.Render("❯ ") + line + "\n"
+		} else {
+			content += "  " + line + "\n"
+		}
+	}
+	return content
+}
@@ -44,6 +44,28 @@ var (
 	categoryStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#00D7FF"))
 
+	projectStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFD75F"))
+
+	contextStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#5FAFAF"))
+
+	dueStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFAF00"))
+
+	overdueStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF5F87")).
+			Bold(true)
+
+	scheduledStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#87AFFF"))
+
+	recurStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#AF87FF"))
+
+	progressStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#87D7AF"))
+
 	statusStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#00D787")).
 			Bold(true)
@@ -55,6 +77,51 @@ var (
 	checkboxStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#585858"))
             
+	markStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF87D7")).
+			Bold(true)
+
+	detailLabelStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#B3B3FF")).
+				Width(11)
+
+	mdHeadingStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFDF5")).
+			Bold(true).
+			Underline(true)
+
+	mdLinkStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#00D7FF")).
+			Underline(true)
+
+	mdCodeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFAF87"))
+
+	boardHeaderStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFFDF5")).
+				Background(lipgloss.Color("#5F5FAF")).
+				Padding(0, 1).
+				Bold(true)
+
+	boardColumnStyle = lipgloss.NewStyle().
+				PaddingRight(1).
+				MarginRight(1).
+				BorderStyle(lipgloss.NormalBorder()).
+				BorderRight(true).
+				BorderForeground(lipgloss.Color("#3A3A3A"))
+
+	calCellStyle = lipgloss.NewStyle().
+			Width(6)
+
+	calTodayStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#00D787")).
+			Bold(true)
+
+	calSelectedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFFDF5")).
+				Background(lipgloss.Color("#6B50FF")).
+				Bold(true)
+
 	checkedStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#00D787"))
 )
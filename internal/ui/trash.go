@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"atlas.todo/internal/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openTrash switches to the trash view.
+func (m Model) openTrash() (tea.Model, tea.Cmd) {
+	m.trash.set(m.store.Trash())
+	m.confirmEmpty = false
+	m.state = browsingTrash
+	return m, nil
+}
+
+// updateTrash handles keys in the trash view: restoring a task, purging
+// it for good, or emptying the whole trash after a confirmation.
+func (m Model) updateTrash(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.confirmEmpty {
+		m.confirmEmpty = false
+		if msg.String() != "y" && msg.String() != "Y" {
+			return m, nil
+		}
+		var ids []string
+		for _, t := range m.trash.tasks {
+			ids = append(ids, t.ID)
+		}
+		purged := m.store.Remove("purge", ids...)
+		_ = m.store.Save()
+		m.trash.set(m.store.Trash())
+		m.statusMsg = fmt.Sprintf("✗ Purged %d task(s)", len(purged))
+		return m, clearStatus()
+	}
+
+	switch msg.String() {
+	case "r", "enter":
+		t, ok := m.trash.selected()
+		if !ok {
+			return m, nil
+		}
+		if err := m.store.Undelete(t.ID); err != nil {
+			m.statusMsg = "⚠ " + err.Error()
+			return m, clearStatus()
+		}
+		_ = m.store.Save()
+		m.trash.set(m.store.Trash())
+		m.statusMsg = "↺ Restored " + t.Title
+		return m, clearStatus()
+	case "x":
+		t, ok := m.trash.selected()
+		if !ok {
+			return m, nil
+		}
+		m.store.Remove("purge", t.ID)
+		_ = m.store.Save()
+		m.trash.set(m.store.Trash())
+		m.statusMsg = "✗ Purged " + t.Title
+		return m, clearStatus()
+	case "X":
+		if len(m.trash.tasks) > 0 {
+			m.confirmEmpty = true
+		}
+	case "esc", "q", "T":
+		m.state = browsing
+	default:
+		m.trash.move(msg.String())
+	}
+	return m, nil
+}
+
+func (m Model) trashView(lines int) string {
+	empty := "The trash is empty."
+	if days := m.store.Config().TrashRetention(); days > 0 {
+		empty += fmt.Sprintf(" Deleted tasks stay here for %d days.", days)
+	}
+	content := m.trash.view("Trash", empty, lines, func(t model.Task) string {
+		return "deleted " + formatDue(*t.DeletedAt, time.Now())
+	})
+
+	switch {
+	case m.confirmEmpty:
+		content += "\n" + deleteWarnStyle.Render(fmt.Sprintf("Permanently delete all %d task(s) in the trash? (y/n)", len(m.trash.tasks)))
+	case m.statusMsg != "":
+		content += "\n" + statusStyle.Render(m.statusMsg)
+	}
+	return content + "\n" + helpStyle.Render("(r/enter: restore • x: purge • X: empty trash • esc: back)")
+}
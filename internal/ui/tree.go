@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"slices"
+
+	"atlas.todo/internal/model"
+	"atlas.todo/internal/storage"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// nest reorders a sorted task list so subtasks follow their parent, keeping
+// the sort order among siblings. Subtasks of collapsed tasks are dropped.
+// A task whose parent didn't make it into the list is shown top-level.
+func nest(tasks []model.Task) []model.Task {
+	present := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		present[t.ID] = true
+	}
+
+	children := map[string][]model.Task{}
+	var roots []model.Task
+	for _, t := range tasks {
+		if t.ParentID != "" && present[t.ParentID] {
+			children[t.ParentID] = append(children[t.ParentID], t)
+		} else {
+			roots = append(roots, t)
+		}
+	}
+
+	out := make([]model.Task, 0, len(tasks))
+	seen := map[string]bool{}
+	var walk func(t model.Task)
+	walk = func(t model.Task) {
+		if seen[t.ID] {
+			return
+		}
+		seen[t.ID] = true
+		out = append(out, t)
+		if t.Collapsed {
+			return
+		}
+		for _, c := range children[t.ID] {
+			walk(c)
+		}
+	}
+	for _, t := range roots {
+		walk(t)
+	}
+	return out
+}
+
+// layout returns the nesting depth of every row in a nested list, and the
+// top-level task each row belongs to (rows are grouped by their root).
+func layout(tasks []model.Task) ([]int, []model.Task) {
+	depths := make([]int, len(tasks))
+	roots := make([]model.Task, len(tasks))
+	index := make(map[string]int, len(tasks))
+
+	for i, t := range tasks {
+		index[t.ID] = i
+		roots[i] = t
+		if p, ok := index[t.ParentID]; ok {
+			depths[i] = depths[p] + 1
+			roots[i] = roots[p]
+		}
+	}
+	return depths, roots
+}
+
+// progress counts the done and total subtasks below id, at any depth.
+func (m Model) progress(id string) (done, total int) {
+	for _, t := range m.store.Descendants(id) {
+		total++
+		if t.Done {
+			done++
+		}
+	}
+	return done, total
+}
+
+// openDescendants returns the IDs of the unfinished subtasks below id.
+func (m Model) openDescendants(id string) []string {
+	var ids []string
+	for _, t := range m.store.Descendants(id) {
+		if !t.Done {
+			ids = append(ids, t.ID)
+		}
+	}
+	return ids
+}
+
+// indentTarget finds the task the row at cursor would be nested under: the
+// closest sibling above it.
+func indentTarget(tasks []model.Task, cursor int) (model.Task, bool) {
+	depths, _ := layout(tasks)
+	for j := cursor - 1; j >= 0; j-- {
+		if depths[j] < depths[cursor] {
+			break
+		}
+		if depths[j] == depths[cursor] {
+			return tasks[j], true
+		}
+	}
+	return model.Task{}, false
+}
+
+// reorder moves the task under the cursor past its previous or next
+// sibling in the same group, keeping the cursor on it.
+func (m Model) reorder(down bool) (tea.Model, tea.Cmd) {
+	tasks := m.filteredTasks()
+	if m.cursor >= len(tasks) {
+		return m, nil
+	}
+	if m.sortByDate {
+		m.statusMsg = "⚠ Tasks keep their own order only in the default sort (press s)"
+		return m, clearStatus()
+	}
+
+	task := tasks[m.cursor]
+	columns := m.store.Config().BoardColumns()
+	sameColumn := func(t model.Task) bool {
+		return !m.board || storage.Column(columns, t) == storage.Column(columns, task)
+	}
+	var siblings []model.Task
+	for _, t := range tasks {
+		if t.ParentID == task.ParentID && m.groupKey(t) == m.groupKey(task) && sameColumn(t) {
+			siblings = append(siblings, t)
+		}
+	}
+	i := slices.IndexFunc(siblings, func(t model.Task) bool { return t.ID == task.ID })
+	step := -1
+	if down {
+		step = 1
+	}
+	if i+step < 0 || i+step >= len(siblings) {
+		return m, nil
+	}
+
+	if err := storage.Move(m.store, task.ID, siblings[i+step].ID, down); err != nil {
+		m.statusMsg = "⚠ " + err.Error()
+		return m, clearStatus()
+	}
+	_ = m.store.Save()
+	m.cursor = max(slices.IndexFunc(m.filteredTasks(), func(t model.Task) bool { return t.ID == task.ID }), 0)
+	return m, nil
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"atlas.todo/internal/model"
+)
+
+func TestNest(t *testing.T) {
+	tests := []struct {
+		name   string
+		tasks  []model.Task
+		want   []string
+		depths []int
+	}{
+		{
+			name: "subtasks follow their parent",
+			tasks: []model.Task{
+				{ID: "b1", ParentID: "b"},
+				{ID: "a"},
+				{ID: "b"},
+				{ID: "a1", ParentID: "a"},
+				{ID: "a1x", ParentID: "a1"},
+				{ID: "a2", ParentID: "a"},
+			},
+			want:   []string{"a", "a1", "a1x", "a2", "b", "b1"},
+			depths: []int{0, 1, 2, 1, 0, 1},
+		},
+		{
+			name: "collapsed hides every level below",
+			tasks: []model.Task{
+				{ID: "a", Collapsed: true},
+				{ID: "a1", ParentID: "a"},
+				{ID: "a1x", ParentID: "a1"},
+				{ID: "b"},
+			},
+			want:   []string{"a", "b"},
+			depths: []int{0, 0},
+		},
+		{
+			name: "parent filtered out",
+			tasks: []model.Task{
+				{ID: "a1", ParentID: "a"},
+				{ID: "b"},
+			},
+			want:   []string{"a1", "b"},
+			depths: []int{0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nested := nest(tt.tasks)
+			var got []string
+			for _, task := range nested {
+				got = append(got, task.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("nest = %q, want %q", got, tt.want)
+			}
+			if depths, _ := layout(nested); !slices.Equal(depths, tt.depths) {
+				t.Errorf("depths %v, want %v", depths, tt.depths)
+			}
+		})
+	}
+}
+
+func TestIndentTarget(t *testing.T) {
+	tasks := nest([]model.Task{
+		{ID: "a"},
+		{ID: "a1", ParentID: "a"},
+		{ID: "a2", ParentID: "a"},
+		{ID: "b"},
+	})
+	tests := []struct {
+		cursor int
+		want   string // "" when the row can't be indented
+	}{
+		{0, ""},
+		{1, ""},
+		{2, "a1"},
+		{3, "a"},
+	}
+	for _, tt := range tests {
+		got, ok := indentTarget(tasks, tt.cursor)
+		if ok != (tt.want != "") || got.ID != tt.want {
+			t.Errorf("indentTarget at row %d = %q, %v; want %q", tt.cursor, got.ID, ok, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"time"
+
+	"atlas.todo/internal/query"
+	"atlas.todo/internal/storage"
+)
+
+// applyView switches to a saved view: its search and view settings. Which
+// view is showing is only screen state, so nothing is saved.
+func (m *Model) applyView(v storage.View) {
+	m.showDone = v.ShowDone
+	m.sortByDate = v.SortByDate
+	m.sortAsc = v.SortAsc
+	m.grouping = Grouping(v.Grouping)
+	m.setSearch(v.Query)
+	m.viewName = v.Name
+	m.cursor = 0
+}
+
+// setSearch replaces the search text and the filter it parses to.
+func (m *Model) setSearch(text string) {
+	m.searchInput.SetValue(text)
+	m.filter, m.filterErr = query.Query{}, nil
+	if q, err := query.Parse(text, time.Now()); err != nil {
+		m.filterErr = err
+	} else {
+		m.filter = q
+	}
+}
+
+// currentView captures what's on screen as a view called name.
+func (m Model) currentView(name string) storage.View {
+	return storage.View{
+		Name:       name,
+		Query:      m.searchInput.Value(),
+		ShowDone:   m.showDone,
+		SortByDate: m.sortByDate,
+		SortAsc:    m.sortAsc,
+		Grouping:   int(m.grouping),
+	}
+}
+
+// viewLabel names the active view for the header, with a * once its
+// settings have been changed on screen.
+func (m Model) viewLabel() string {
+	if m.viewName == "" {
+		return ""
+	}
+	v, ok := m.store.Config().View(m.viewName)
+	if !ok {
+		return ""
+	}
+	if v != m.currentView(v.Name) {
+		return v.Name + "*"
+	}
+	return v.Name
+}
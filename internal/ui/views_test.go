@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"testing"
+
+	"atlas.todo/internal/storage"
+)
+
+func TestApplyView(t *testing.T) {
+	m, store := newTestModel("a", "b")
+	cfg := store.Config()
+	cfg.SetView(storage.View{Name: "everything", Query: "a", ShowDone: true, Grouping: int(GroupPriority)})
+	store.SetConfig(cfg)
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	before := store.Config()
+
+	m = press(m, "1")
+	if m.viewName != "everything" || !m.showDone || m.grouping != GroupPriority || len(m.filteredTasks()) != 1 {
+		t.Errorf("view %q, show done %v, grouping %v, %d task(s) after 1; want the saved view",
+			m.viewName, m.showDone, m.grouping, len(m.filteredTasks()))
+	}
+	if m.viewLabel() != "everything" {
+		t.Errorf("header label %q, want everything", m.viewLabel())
+	}
+
+	// Switching views changes nothing in the store, so undo still undoes
+	// the config change above
+	if got := store.Config(); got.ShowDone != before.ShowDone || got.Grouping != before.Grouping {
+		t.Errorf("switching views saved its settings as the defaults")
+	}
+	if _, err := store.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.Config().View("everything"); ok {
+		t.Error("undo undid the view switch, not the view being saved")
+	}
+}
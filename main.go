@@ -3,12 +3,15 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"atlas.todo/internal/model"
+	"atlas.todo/internal/query"
 	"atlas.todo/internal/storage"
 	"atlas.todo/internal/ui"
 )
@@ -16,12 +19,68 @@ import (
 var Version = "dev"
 
 func main() {
-	if len(os.Args) > 1 && (os.Args[1] == "-v" || os.Args[1] == "--version") {
+	opts, args, err := parseGlobalFlags(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(args) > 0 && (args[0] == "-v" || args[0] == "--version") {
 		fmt.Printf("atlas.todo v%s\n", Version)
 		return
 	}
 
-	store, err := storage.NewStore()
+	if len(args) > 0 && args[0] == "init" {
+		path, err := storage.InitProject(".")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Created %s. atlas.todo will use it anywhere below this directory.\n", path)
+		fmt.Println("Commit it to share tasks with your team, and ignore the local state next to it:")
+		fmt.Printf("  echo '%s.*' >> .gitignore\n", storage.ProjectFile)
+		fmt.Printf("  echo '%s' >> .gitignore\n", strings.TrimSuffix(storage.ProjectFile, ".json")+".journal")
+		return
+	}
+
+	lists, err := storage.NewLists()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing store: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Which file: --file, then --list, then $ATLAS_TODO_FILE, then a project
+	// file above the working directory, then the default list
+	listLabel := opts.list
+	path := opts.file
+	switch {
+	case path != "":
+		listLabel = displayPath(path)
+	case opts.list == "" && os.Getenv("ATLAS_TODO_FILE") == "":
+		if project, ok := storage.FindProjectFile("."); ok {
+			path = project
+			listLabel = displayPath(path)
+			break
+		}
+		fallthrough
+	default:
+		path, err = lists.Path(opts.list)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if opts.list == "" && os.Getenv("ATLAS_TODO_FILE") != "" {
+			listLabel = displayPath(path)
+		}
+	}
+
+	// The prompt runs on every shell prompt, so it skips loading the tasks
+	if len(args) > 0 && args[0] == "prompt" {
+		cmdPrompt(path, args[1:])
+		return
+	}
+
+	store, err := storage.NewJSONStore(path)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error initializing store: %v\n", err)
 		os.Exit(1)
@@ -31,75 +90,109 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Error loading tasks: %v\n", err)
 		os.Exit(1)
 	}
+	if _, err := storage.PurgeExpired(store, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not empty the trash: %v\n", err)
+	}
 
 	// CLI Mode: Handle arguments
-	if len(os.Args) > 1 {
-		cmd := os.Args[1]
+	if len(args) > 0 {
+		cmd := args[0]
 		switch cmd {
 		case "add":
-			if len(os.Args) < 3 {
-				fmt.Println("Usage: atlas.todo add \"Task text\"")
-				os.Exit(1)
-			}
-			text := strings.Join(os.Args[2:], " ")
-			task := model.ParseTask(text)
-			
-			store.Add(task)
-			if err := store.Save(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error saving task: %v\n", err)
-				os.Exit(1)
+			if len(args) < 2 {
+				usage("add \"Task text\"")
 			}
-			fmt.Printf("Task added: %s\n", task.Title)
+			text := strings.Join(args[1:], " ")
+			task := store.Add(model.ParseTask(text))
+			save(store)
+			// Just the ID, so scripts can capture it: id=$(atlas.todo add ...)
+			fmt.Println(store.ShortIDs()[task.ID])
 			return
 		case "list":
+			out, rest := parseOutputFlags(store, args[1:])
+
 			// Default values
 			limit := 5
 			sortOrder := "default"
 
-			// Parse arguments
-			if len(os.Args) > 2 {
-				for _, arg := range os.Args[2:] {
-					if arg == "asc" || arg == "desc" {
-						sortOrder = arg
-					} else {
-						if val, err := strconv.Atoi(arg); err == nil {
-							limit = val
-						}
+			// Parse arguments; anything that isn't a count, order or view is
+			// the query. Only the first number is the count, so later ones
+			// like 2026 can be searched for.
+			var view storage.View
+			var terms []string
+			counted := false
+			for i := 0; i < len(rest); i++ {
+				arg := rest[i]
+				if name, ok := strings.CutPrefix(arg, "--view="); ok {
+					view = findView(store, name)
+				} else if arg == "--view" {
+					if i+1 >= len(rest) {
+						usage("list [n] [asc|desc] [--view name] [query]")
+					}
+					i++
+					view = findView(store, rest[i])
+				} else if arg == "asc" || arg == "desc" {
+					sortOrder = arg
+				} else if val, err := strconv.Atoi(arg); err == nil && !counted {
+					if val < 1 {
+						fail(exitUsage, "the number of tasks to list must be at least 1")
 					}
+					limit = val
+					counted = true
+				} else {
+					terms = append(terms, arg)
 				}
 			}
-
-			// Filter pending tasks
-			var pending []model.Task
-			for _, t := range store.Tasks {
-				if !t.Done {
-					pending = append(pending, t)
-				}
+			text := strings.TrimSpace(view.Query + " " + strings.Join(terms, " "))
+			q, err := query.Parse(text, time.Now())
+			if err != nil {
+				failQuery(text, err)
 			}
 
+			// Filter pending tasks, unless the query or view says which
+			pending := store.Query(func(t model.Task) bool {
+				return (view.ShowDone || q.MentionsDone() || !t.Done) && q.Match(t)
+			})
+
 			// Sort
+			if view.SortByDate {
+				sort.SliceStable(pending, func(i, j int) bool {
+					if view.SortAsc {
+						return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+					}
+					return pending[i].CreatedAt.After(pending[j].CreatedAt)
+				})
+			}
 			if sortOrder == "asc" {
-				sort.Slice(pending, func(i, j int) bool {
+				sort.SliceStable(pending, func(i, j int) bool {
 					return pending[i].Priority < pending[j].Priority
 				})
 			} else if sortOrder == "desc" {
-				sort.Slice(pending, func(i, j int) bool {
+				sort.SliceStable(pending, func(i, j int) bool {
 					return pending[i].Priority > pending[j].Priority
 				})
 			}
 
+			if len(pending) > limit {
+				pending = pending[:limit]
+			}
+			if out.machine() {
+				emit(out, pending)
+				return
+			}
+
 			// Print
+			if len(pending) == 0 && !q.Empty() {
+				fmt.Println("No matching tasks.")
+				return
+			}
 			if len(pending) == 0 {
 				fmt.Println("No pending tasks! 🎉")
 				return
 			}
 
-			count := 0
+			shortIDs := store.ShortIDs()
 			for _, t := range pending {
-				if count >= limit {
-					break
-				}
-
 				prioMarker := " "
 				if t.Priority == model.PriorityHigh {
 					prioMarker = "!"
@@ -107,8 +200,173 @@ func main() {
 					prioMarker = "."
 				}
 
-				fmt.Printf("[%s] %s\n", prioMarker, t.Format())
-				count++
+				fmt.Printf("[%s] %s %s\n", prioMarker, shortIDs[t.ID], t.Format())
+			}
+			return
+		case "done", "reopen":
+			cmdDone(store, cmd, args[1:])
+			return
+		case "rm":
+			cmdRemove(store, args[1:])
+			return
+		case "edit":
+			cmdEdit(store, args[1:])
+			return
+		case "prio":
+			cmdPrio(store, args[1:])
+			return
+		case "show":
+			cmdShow(store, args[1:])
+			return
+		case "status":
+			cmdStatus(store, args[1:])
+			return
+		case "agenda":
+			cmdAgenda(store, args[1:])
+			return
+		case "dashboard":
+			cmdDashboard(store, lists.Dir, args[1:])
+			return
+		case "columns":
+			cmdColumns(store, args[1:])
+			return
+		case "trash":
+			cmdTrash(store, args[1:])
+			return
+		case "archive":
+			cmdArchive(store, args[1:])
+			return
+		case "views":
+			cmdViews(store, args[1:])
+			return
+		case "import":
+			cmdImport(store, args[1:])
+			return
+		case "export":
+			cmdExport(store, args[1:])
+			return
+		case "sync":
+			cmdSync(store, path, args[1:])
+			return
+		case "restore":
+			backups, err := store.Backups()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading backups: %v\n", err)
+				os.Exit(1)
+			}
+
+			if len(args) < 2 {
+				if len(backups) == 0 {
+					fmt.Println("No backups yet.")
+					return
+				}
+				fmt.Println("Available backups (newest first):")
+				for _, b := range backups {
+					count := "unreadable"
+					if b.Tasks >= 0 {
+						count = fmt.Sprintf("%d tasks", b.Tasks)
+					}
+					fmt.Printf("  %d  %s  %s\n", b.N, b.ModTime.Format("2006-01-02 15:04:05"), count)
+				}
+				fmt.Println("\nRun 'atlas.todo restore <n>' to roll back to one of them.")
+				return
+			}
+
+			n, err := strconv.Atoi(args[1])
+			if err != nil || len(args) > 2 {
+				usage("restore [n]")
+			}
+			if err := store.Restore(n); err != nil {
+				fmt.Fprintf(os.Stderr, "Error restoring backup: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Restored backup #%d (%d tasks).\n", n, len(store.Query(nil)))
+			return
+		case "undo", "redo":
+			op := store.Undo
+			verb := "Undid"
+			if cmd == "redo" {
+				op, verb = store.Redo, "Redid"
+			}
+			what, err := op()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if err := store.Save(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error saving tasks: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("%s %s\n", verb, what)
+			return
+		case "log":
+			out, rest := parseOutputFlags(store, args[1:])
+			id := ""
+			if len(rest) > 0 {
+				task, err := store.Find(rest[0])
+				if err == nil {
+					id = task.ID
+				} else if len(store.History(rest[0])) > 0 {
+					id = rest[0] // Deleted tasks only live on in the journal
+				} else {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			events := store.History(id)
+			if id == "" && len(events) > 20 {
+				events = events[len(events)-20:]
+			}
+			if out.machine() {
+				emit(out, events)
+				return
+			}
+			if len(events) == 0 {
+				fmt.Println("No history yet.")
+				return
+			}
+			for _, e := range events {
+				fmt.Printf("%s  %s\n", e.Time.Local().Format("2006-01-02 15:04:05"), e.Describe())
+			}
+			return
+		case "lists":
+			out, _ := parseOutputFlags(store, args[1:])
+			names, err := lists.Names()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading lists: %v\n", err)
+				os.Exit(1)
+			}
+			// A file given by --file, $ATLAS_TODO_FILE or a project isn't
+			// one of the lists, even if it's what the default list points at
+			fileOpen := opts.list == "" && listLabel != ""
+			active := opts.list
+			if active == "" {
+				active = storage.DefaultList
+			}
+			var infos []listInfo
+			for _, name := range names {
+				p, _ := lists.Path(name)
+				info := listInfo{Name: name, Path: p, Active: !fileOpen && p == path && name == active}
+				if s, err := lists.Open(name); err == nil {
+					info.Pending = len(s.Query(func(t model.Task) bool { return !t.Done }))
+				}
+				infos = append(infos, info)
+			}
+			if out.machine() {
+				emit(out, infos)
+				return
+			}
+
+			for _, info := range infos {
+				marker := " "
+				if info.Active {
+					marker = "*"
+				}
+				fmt.Printf("%s %-20s %d pending\n", marker, info.Name, info.Pending)
+			}
+			if fileOpen {
+				fmt.Printf("* %s\n", listLabel)
 			}
 			return
 		case "help", "--help", "-h":
@@ -118,25 +376,145 @@ func main() {
 	}
 
 	// TUI Mode
-	p := tea.NewProgram(ui.NewModel(store), tea.WithAltScreen())
+	p := tea.NewProgram(ui.NewModel(store, lists, listLabel), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// displayPath shortens paths under the home directory to ~/...
+func displayPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if rel, err := filepath.Rel(home, abs); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.Join("~", rel)
+		}
+	}
+	return abs
+}
+
+// listInfo is one line of 'atlas.todo lists'.
+type listInfo struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Pending int    `json:"pending"`
+	Active  bool   `json:"active"`
+}
+
+type globalOptions struct {
+	file string // --file: use this task file
+	list string // --list: use this named list
+}
+
+// parseGlobalFlags strips the options that may precede any command and
+// returns the remaining arguments.
+func parseGlobalFlags(args []string) (globalOptions, []string, error) {
+	var opts globalOptions
+	for len(args) > 0 {
+		name, value, hasValue := strings.Cut(args[0], "=")
+		var target *string
+		switch name {
+		case "--file":
+			target = &opts.file
+		case "--list":
+			target = &opts.list
+		default:
+			return opts, args, nil
+		}
+
+		if !hasValue {
+			if len(args) < 2 {
+				return opts, nil, fmt.Errorf("%s needs a value", name)
+			}
+			value = args[1]
+			args = args[1:]
+		}
+		*target = value
+		args = args[1:]
+	}
+	return opts, args, nil
+}
+
 func showHelp() {
 	fmt.Println("Atlas Todo - A fast, minimalist task manager for your terminal.")
 	fmt.Println("\nUsage:")
+	fmt.Println("  atlas.todo [--list name | --file path] [command]")
+	fmt.Println("\nCommands:")
 	fmt.Println("  atlas.todo               Start the interactive TUI")
 	fmt.Println("  atlas.todo add \"[task]\"  Quickly add a task via command line")
 	fmt.Println("  atlas.todo list [opts]   List pending tasks (useful for MOTD)")
+	fmt.Println("  atlas.todo done <id>     Mark a task done (reopen <id> undoes it)")
+	fmt.Println("  atlas.todo rm <id>       Move a task to the trash")
+	fmt.Println("  atlas.todo trash         Show deleted tasks (trash restore <id>, trash purge [id],")
+	fmt.Println("                           trash keep <days>|forever)")
+	fmt.Println("  atlas.todo edit <id> \"[task]\"  Replace a task's text, parsed like add")
+	fmt.Println("  atlas.todo prio <id> <p> Set priority: high, med or low")
+	fmt.Println("  atlas.todo show <id>     Show all of a task's details")
+	fmt.Println("  atlas.todo status <id> <status>")
+	fmt.Println("                           Move a task to a board column, e.g. doing")
+	fmt.Println("  atlas.todo columns       Show the board columns (columns <name...> sets them,")
+	fmt.Println("                           columns reset goes back to todo doing blocked done)")
+	fmt.Println("  atlas.todo agenda [--days 7]")
+	fmt.Println("                           Show overdue tasks and what's planned or due each day")
+	fmt.Println("  atlas.todo dashboard [--top 3] [--banner file | --no-banner]")
+	fmt.Println("                           Greeting, top tasks, overdue count and streak for a new shell")
+	fmt.Println("  atlas.todo prompt [format]")
+	fmt.Println("                           Print task counts for a shell prompt, e.g. '{pending} {high}!'")
+	fmt.Println("  atlas.todo archive [--older-than 30d]")
+	fmt.Println("                           Move finished tasks to the archive")
+	fmt.Println("  atlas.todo archive list  Show archived tasks (archive restore <id> brings one back)")
+	fmt.Println("  atlas.todo import todotxt <file>")
+	fmt.Println("                           Add the tasks in a todo.txt file (- reads stdin)")
+	fmt.Println("  atlas.todo export todotxt")
+	fmt.Println("                           Print every task as todo.txt")
+	fmt.Println("  atlas.todo sync todotxt <file>")
+	fmt.Println("                           Sync a todo.txt file with your tasks both ways")
+	fmt.Println("  atlas.todo lists         Show your task lists")
+	fmt.Println("  atlas.todo views         Show saved views (views add <name> <query>, views rm <name>)")
+	fmt.Println("  atlas.todo init          Create a project task file in this directory")
+	fmt.Println("  atlas.todo undo          Undo the last change")
+	fmt.Println("  atlas.todo redo          Redo the last undone change")
+	fmt.Println("  atlas.todo log [id]      Show the history of a task, or recent changes")
+	fmt.Println("  atlas.todo restore [n]   List backups, or roll back to backup n")
 	fmt.Println("  atlas.todo help          Show this help information")
 	fmt.Println("\nList Options:")
 	fmt.Println("  atlas.todo list          Show top 5 tasks (default order)")
 	fmt.Println("  atlas.todo list 3        Show top 3 tasks")
 	fmt.Println("  atlas.todo list asc      Show tasks sorted by priority (Low -> High)")
 	fmt.Println("  atlas.todo list desc 10  Show top 10 tasks sorted by priority (High -> Low)")
+	fmt.Println("  atlas.todo list 20 cat:work prio:>=med due:<7d")
+	fmt.Println("                           Show tasks matching a query (see Queries)")
+	fmt.Println("  atlas.todo list --view today")
+	fmt.Println("                           Show the tasks in a saved view")
+	fmt.Println("\nQueries ('list <query>' and / in the TUI):")
+	fmt.Println("  Terms are separated by spaces and must all match; -term excludes.")
+	fmt.Println("  word, \"some words\"     Title or description contains the text")
+	fmt.Println("  text:x, title:x         The same, or the title only")
+	fmt.Println("  @x, cat:x, ctx:x        Category or context, category, context")
+	fmt.Println("  +x, project:x           Project")
+	fmt.Println("  !high, prio:>=med       Priority, compared with = != < <= > >=")
+	fmt.Println("  due:<7d, due:today      Due date: a date, 7d / -7d, this-week, next-month,")
+	fmt.Println("  created:this-week       last-year, ..., or none / any")
+	fmt.Println("  done, is:open           Completion (is:overdue, is:recurring, is:subtask)")
+	fmt.Println("  completed:<-30d         Completion date")
+	fmt.Println("  sched:this-week         Scheduled date (also created:, completed:)")
+	fmt.Println("  status:doing            Board column")
+	fmt.Println("  id:01M57                ID prefix")
+	fmt.Println("\nScript Output (list, show, log, lists):")
+	fmt.Println("  --json           Print full records as a JSON array (an object for show)")
+	fmt.Println("  --ndjson         Print one JSON record per line")
+	fmt.Println("  --format <tmpl>  Print each record with a Go text/template, e.g.")
+	fmt.Println("                   --format '{{short .ID}}\\t{{.Priority}}\\t{{.Title}}'")
+	fmt.Println("                   Helpers: short (short ID), date (YYYY-MM-DD), join")
+	fmt.Println("\nTask IDs:")
+	fmt.Println("  'list' prints each task's ID. Anywhere an ID is expected you can type")
+	fmt.Println("  just enough of its start to be unique, like a git commit hash.")
+	fmt.Println("  done, reopen and rm accept several IDs. Exit codes: 0 success, 1 error,")
+	fmt.Println("  2 bad usage, 3 no such task, 4 ambiguous ID prefix.")
 	fmt.Println("\nNote: When using 'add' from CLI, wrap your task in quotes if it contains")
 	fmt.Println("      special characters or metadata like @category or !priority.")
 	fmt.Println("      Example: atlas.todo add \"Buy milk @grocery !high\"")
@@ -144,14 +522,55 @@ func showHelp() {
 	fmt.Println("  j/k, up/down   Navigate through tasks")
 	fmt.Println("  Space          Toggle task completion")
 	fmt.Println("  n              Add a new task")
-	fmt.Println("  /              Search tasks")
-	fmt.Println("  d              Delete selected task")
+	fmt.Println("  enter, l       Show task details; e there edits the description")
+	fmt.Println("  /              Search tasks with a query (see Queries)")
+	fmt.Println("  d              Move selected task to the trash")
+	fmt.Println("  T              Browse the trash to restore or purge tasks")
+	fmt.Println("  A              Browse the archive to restore tasks")
 	fmt.Println("  s              Toggle sort by date added")
 	fmt.Println("  c              Toggle showing completed tasks")
-	fmt.Println("  q, esc         Quit the application")
+	fmt.Println("  u              Undo the last change")
+	fmt.Println("  ctrl+r         Redo the last undone change")
+	fmt.Println("  w              Switch task list")
+	fmt.Println("  1-9, 0         Open a saved view, or leave it")
+	fmt.Println("  S              Save the search and view settings as a view")
+	fmt.Println("  J/K            Move task down/up within its group")
+	fmt.Println("  b              Toggle the kanban board; left/right switch columns")
+	fmt.Println("  H/L            Move a card to the previous/next board column")
+	fmt.Println("  C              Month calendar; tab there shows the 7-day agenda")
+	fmt.Println("  >, tab         Nest task under the one above it")
+	fmt.Println("  <, shift+tab   Move subtask up a level")
+	fmt.Println("  z              Fold or unfold subtasks")
+	fmt.Println("  p, @           Set priority (h/m/l) or category")
+	fmt.Println("  y              Copy title to the clipboard")
+	fmt.Println("  v, V           Mark task, or mark the range from the last mark")
+	fmt.Println("  *              Mark every task the search shows")
+	fmt.Println("  esc            Clear the marks")
+	fmt.Println("                 Space, d, p, @ and y act on every marked task")
+	fmt.Println("  q              Quit the application")
 	fmt.Println("\nMetadata Parsing:")
 	fmt.Println("  Include '!high' in your task title to set high priority.")
+	fmt.Println("  The first '@word' sets the category; any further '@words' are contexts.")
+	fmt.Println("  Include '+project' to file the task under a project.")
+	fmt.Println("  Include 'due:<when>' to set a due date, where <when> is a date")
+	fmt.Println("  (2026-11-01), today, tomorrow, a weekday (fri), an offset (+3d, +2w)")
+	fmt.Println("  or eow/eom/eoy for the end of the week, month or year.")
+	fmt.Println("  Include 'sched:<when>' to plan the day you'll work on it.")
+	fmt.Println("  Include 'rec:<rule>' to repeat a task when it is completed, where <rule>")
+	fmt.Println("  is daily, weekly, weekly:mon, monthly, monthly:15, yearly, or an interval")
+	fmt.Println("  measured from completion such as +3d or +2w.")
 	fmt.Println("\nStorage:")
 	fmt.Println("  Tasks are stored locally in ~/.atlas/todo.json.")
+	fmt.Println("  Named lists (--list work) live in ~/.atlas/lists/<name>.json and are")
+	fmt.Println("  created on first use. --file points at any task file instead.")
+	fmt.Println("  Set ATLAS_HOME to move ~/.atlas, or ATLAS_TODO_FILE to replace todo.json.")
+	fmt.Println("  Inside a directory with a .atlas-todo.json (or below one), that file is")
+	fmt.Println("  used instead, like git finds .git. Use --list default for your own list.")
 	fmt.Println("  The directory and file will be created automatically on first run.")
+	fmt.Println("  Saves are atomic, and the last few versions are kept as backups")
+	fmt.Println("  (todo.json.1, todo.json.2, ...) for 'atlas.todo restore'.")
+	fmt.Println("  Several sessions can share the file: saves are locked and merged per")
+	fmt.Println("  task, and an open TUI picks up changes made elsewhere.")
+	fmt.Println("  Every change is also recorded in ~/.atlas/todo.journal, the history")
+	fmt.Println("  behind undo, redo and 'atlas.todo log'.")
 }
\ No newline at end of file
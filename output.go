@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"atlas.todo/internal/model"
+	"atlas.todo/internal/storage"
+	"github.com/charmbracelet/x/term"
+)
+
+// output is how a query command prints its results: text for people by
+// default, or JSON, NDJSON or a text/template for scripts.
+type output struct {
+	json   bool
+	ndjson bool
+	format *template.Template
+}
+
+// parseOutputFlags strips --json, --ndjson and --format from a command's
+// arguments. The template can use short (a task ID's short form), date
+// (YYYY-MM-DD, empty for no date) and join, and \t and \n in it are turned
+// into tabs and newlines.
+func parseOutputFlags(store storage.Backend, args []string) (output, []string) {
+	var out output
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		switch name {
+		case "--json":
+			out.json = true
+		case "--ndjson":
+			out.ndjson = true
+		case "--format":
+			if !hasValue {
+				if i+1 >= len(args) {
+					fail(exitUsage, "--format needs a template, e.g. --format '{{.ID}}\\t{{.Title}}'")
+				}
+				i++
+				value = args[i]
+			}
+			text := strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(value)
+			tmpl, err := template.New("format").Funcs(templateFuncs(store)).Parse(text)
+			if err != nil {
+				fail(exitUsage, "bad --format template: %v", err)
+			}
+			out.format = tmpl
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+
+	n := 0
+	for _, set := range []bool{out.json, out.ndjson, out.format != nil} {
+		if set {
+			n++
+		}
+	}
+	if n > 1 {
+		fail(exitUsage, "use only one of --json, --ndjson and --format")
+	}
+	return out, rest
+}
+
+func templateFuncs(store storage.Backend) template.FuncMap {
+	var shortIDs map[string]string
+	return template.FuncMap{
+		"short": func(id string) string {
+			if shortIDs == nil {
+				shortIDs = store.ShortIDs()
+			}
+			if s, ok := shortIDs[id]; ok {
+				return s
+			}
+			return id
+		},
+		"date": func(v any) string {
+			switch d := v.(type) {
+			case time.Time:
+				return d.Local().Format(model.DateLayout)
+			case *time.Time:
+				if d != nil {
+					return d.Local().Format(model.DateLayout)
+				}
+			}
+			return ""
+		},
+		"join": func(items []string, sep string) string {
+			return strings.Join(items, sep)
+		},
+	}
+}
+
+// machine reports whether a script asked for machine-readable output.
+func (o output) machine() bool {
+	return o.json || o.ndjson || o.format != nil
+}
+
+// emit prints items in the chosen machine-readable format: a JSON array,
+// one JSON object per line, or the template once per item.
+func emit[T any](o output, items []T) {
+	if items == nil {
+		items = []T{} // [] rather than null
+	}
+	switch {
+	case o.json:
+		writeJSON(items)
+	case o.ndjson:
+		enc := json.NewEncoder(os.Stdout)
+		for _, item := range items {
+			if err := enc.Encode(item); err != nil {
+				fail(exitError, "%v", err)
+			}
+		}
+	case o.format != nil:
+		for _, item := range items {
+			if err := o.format.Execute(os.Stdout, item); err != nil {
+				fail(exitError, "--format: %v", err)
+			}
+			fmt.Println()
+		}
+	}
+}
+
+// emitOne prints a single result; with --json it's an object, not an array.
+func emitOne[T any](o output, item T) {
+	if o.json {
+		writeJSON(item)
+		return
+	}
+	emit(o, []T{item})
+}
+
+func writeJSON(v any) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		fail(exitError, "%v", err)
+	}
+	fmt.Println(string(data))
+}
+
+// terminalWidth is how wide stdout is, or 0 when it isn't a terminal and
+// text shouldn't be wrapped.
+func terminalWidth() int {
+	w, _, err := term.GetSize(os.Stdout.Fd())
+	if err != nil {
+		return 0
+	}
+	return w
+}
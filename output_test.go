@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"atlas.todo/internal/model"
+)
+
+func TestOutputFlags(t *testing.T) {
+	path := seed(t,
+		model.Task{ID: "01ABCDEF0000", Title: "Buy milk", Category: "store"},
+		model.Task{ID: "01ZZZZZZ0000", Title: "Call bank"},
+	)
+
+	out, _, code := run(t, path, "list", "--json")
+	var tasks []model.Task
+	if err := json.Unmarshal([]byte(out), &tasks); err != nil || code != 0 {
+		t.Fatalf("list --json: %v, exit code %d:\n%s", err, code, out)
+	}
+	if len(tasks) != 2 {
+		t.Errorf("list --json printed %d task(s), want 2", len(tasks))
+	}
+
+	out, _, _ = run(t, path, "list", "--ndjson")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	for _, line := range lines {
+		var task model.Task
+		if err := json.Unmarshal([]byte(line), &task); err != nil {
+			t.Errorf("list --ndjson line %q: %v", line, err)
+		}
+	}
+	if len(lines) != 2 {
+		t.Errorf("list --ndjson printed %d line(s), want 2", len(lines))
+	}
+
+	out, _, _ = run(t, path, "list", `--format={{short .ID}}\t{{.Category}}\t{{.Title}}`)
+	lines = strings.Split(strings.TrimSpace(out), "\n")
+	slices.Sort(lines)
+	if want := []string{"01ABCD\tstore\tBuy milk", "01ZZZZ\t\tCall bank"}; !slices.Equal(lines, want) {
+		t.Errorf("list --format printed %q, want %q", lines, want)
+	}
+
+	out, _, _ = run(t, path, "show", "01ABCD", "--json")
+	var task model.Task
+	if err := json.Unmarshal([]byte(out), &task); err != nil || task.Title != "Buy milk" {
+		t.Errorf("show --json printed %q (%v), want the task as an object", out, err)
+	}
+
+	out, _, _ = run(t, path, "list", "nothing-matches-this", "--json")
+	if strings.TrimSpace(out) != "[]" {
+		t.Errorf("list --json with no results printed %q, want []", out)
+	}
+}
+
+func TestOutputFlagErrors(t *testing.T) {
+	path := seed(t, model.Task{ID: "01ABCDEF0000", Title: "Buy milk"})
+	for _, args := range [][]string{
+		{"list", "--json", "--ndjson"},
+		{"list", "--format", "{{"},
+		{"list", "--format"},
+	} {
+		if _, _, code := run(t, path, args...); code != exitUsage {
+			t.Errorf("%q: exit code %d, want %d", args, code, exitUsage)
+		}
+	}
+}
+
+func TestAgendaOutput(t *testing.T) {
+	today := model.StartOfDay(time.Now())
+	yesterday, tomorrow, later := today.AddDate(0, 0, -1), today.AddDate(0, 0, 1), today.AddDate(0, 0, 10)
+	path := seed(t,
+		model.Task{ID: "a", Title: "late", CreatedAt: yesterday, Due: &yesterday},
+		model.Task{ID: "b", Title: "planned", CreatedAt: yesterday, Scheduled: &tomorrow},
+		model.Task{ID: "c", Title: "far off", CreatedAt: yesterday, Scheduled: &later},
+	)
+
+	out, _, code := run(t, path, "agenda", "--days", "3", "--format", "{{.Day}} {{.Title}} {{.Overdue}}")
+	want := " late true\n" + tomorrow.Format(model.DateLayout) + " planned false\n"
+	if out != want || code != 0 {
+		t.Errorf("agenda --format printed %q, exit code %d; want %q", out, code, want)
+	}
+
+	out, _, _ = run(t, path, "agenda", "--days=14", "--json")
+	var entries []map[string]any
+	if err := json.Unmarshal([]byte(out), &entries); err != nil || len(entries) != 3 {
+		t.Errorf("agenda --json printed %d entries (%v), want 3:\n%s", len(entries), err, out)
+	}
+}